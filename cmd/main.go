@@ -4,18 +4,26 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata" // series timezones must resolve even without system zoneinfo
 
 	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/database"
 	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/handler"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/jobs"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/notify"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/payment"
 	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
 	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/service"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/webhook"
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 )
@@ -34,10 +42,134 @@ func main() {
 	// ── 2. Wire up layers ────────────────────────────────────────────────
 	eventRepo := repository.NewEventRepository(pool)
 	regRepo := repository.NewRegistrationRepository(pool)
-	eventSvc := service.NewEventService(eventRepo, regRepo)
-	eventHandler := handler.NewEventHandler(eventSvc)
+	appRepo := repository.NewApplicationRepository(pool)
+	ballotRepo := repository.NewBallotRepository(pool)
+	inviteRepo := repository.NewInviteRepository(pool)
+	allocRepo := repository.NewAllocationRepository(pool)
+	baseURL := getEnv("PUBLIC_BASE_URL", "http://localhost:8080")
+	// DEV_ROUTES enables development conveniences: the fake provider's
+	// built-in callback secret and the route that settles fake payments.
+	// Choosing PAYMENT_PROVIDER=fake explicitly also exposes the route.
+	devRoutes := getEnv("DEV_ROUTES", "false") == "true"
+	simulatePayments := devRoutes || os.Getenv("PAYMENT_PROVIDER") == "fake"
+	provider, err := payment.NewFromEnv(baseURL, devRoutes)
+	if err != nil {
+		log.Fatalf("payments: %v", err)
+	}
+	orderHold, err := time.ParseDuration(getEnv("ORDER_HOLD_TIME", "15m"))
+	if err != nil {
+		log.Fatalf("ORDER_HOLD_TIME: %v", err)
+	}
+	eventSvc := service.NewEventService(eventRepo, regRepo, appRepo, ballotRepo, inviteRepo, allocRepo,
+		repository.NewOrderRepository(pool), service.PaymentConfig{Provider: provider, Hold: orderHold})
+	// LEGACY_LIST_ARRAYS keeps GET /events and GET /events/{id}/registrations
+	// returning bare arrays when called without limit/cursor, as the bundled
+	// web pages still expect.
+	eventHandler := handler.NewEventHandler(eventSvc, getEnv("LEGACY_LIST_ARRAYS", "true") == "true")
+	venueHandler := handler.NewVenueHandler(service.NewVenueService(repository.NewVenueRepository(pool)))
+	sessionHandler := handler.NewSessionHandler(service.NewSessionService(repository.NewSessionRepository(pool)))
+	seriesHandler := handler.NewSeriesHandler(service.NewSeriesService(repository.NewSeriesRepository(pool), eventRepo))
+	tagHandler := handler.NewTagHandler(service.NewTagService(repository.NewTagRepository(pool)))
+	outboxRepo := repository.NewOutboxRepository(pool)
+	templateRepo := repository.NewTemplateRepository(pool)
+	templateHandler := handler.NewTemplateHandler(service.NewTemplateService(eventRepo, templateRepo))
+	webhookRepo := repository.NewWebhookRepository(pool)
+	webhookHandler := handler.NewWebhookHandler(service.NewWebhookService(eventRepo, webhookRepo))
+	jobRepo := repository.NewJobRepository(pool)
+	jobHandler := handler.NewJobHandler(service.NewJobService(jobRepo))
+
+	// ── 3. Start background workers ───────────────────────────────────────
+	notifier, err := notify.NewFromEnv()
+	if err != nil {
+		log.Fatalf("notifier: %v", err)
+	}
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	var workers sync.WaitGroup
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		notify.NewDispatcher(outboxRepo, eventRepo, templateRepo, notifier).Run(workerCtx)
+	}()
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		webhook.NewDispatcher(webhookRepo).Run(workerCtx)
+	}()
+	log.Println("✓ Notification and webhook dispatchers started")
+
+	reminderLead, err := time.ParseDuration(getEnv("REMINDER_LEAD_TIME", "24h"))
+	if err != nil {
+		log.Fatalf("REMINDER_LEAD_TIME: %v", err)
+	}
+	jobWorkers, err := strconv.Atoi(getEnv("JOB_WORKERS", "4"))
+	if err != nil {
+		log.Fatalf("JOB_WORKERS: %v", err)
+	}
+	runner := jobs.NewRunner(jobRepo, jobWorkers)
+	runner.Handle("reminders.queue", func(ctx context.Context, _ json.RawMessage) error {
+		n, err := eventSvc.QueueReminders(ctx, reminderLead)
+		if n > 0 {
+			log.Printf("reminders: queued %d", n)
+		}
+		return err
+	})
+	runner.Handle("ballots.draw", func(ctx context.Context, _ json.RawMessage) error {
+		n, err := eventSvc.DrawDueBallots(ctx)
+		if n > 0 {
+			log.Printf("ballots: drew %d", n)
+		}
+		return err
+	})
+	runner.Handle("orders.expire", func(ctx context.Context, _ json.RawMessage) error {
+		n, err := eventSvc.ExpireOrders(ctx)
+		if n > 0 {
+			log.Printf("orders: expired %d", n)
+		}
+		return err
+	})
+	runner.Handle("refunds.process", func(ctx context.Context, _ json.RawMessage) error {
+		n, err := eventSvc.ProcessRefunds(ctx)
+		if n > 0 {
+			log.Printf("refunds: attempted %d", n)
+		}
+		return err
+	})
+	batchSize, err := strconv.Atoi(getEnv("BROADCAST_BATCH_SIZE", "50"))
+	if err != nil {
+		log.Fatalf("BROADCAST_BATCH_SIZE: %v", err)
+	}
+	batchInterval, err := time.ParseDuration(getEnv("BROADCAST_BATCH_INTERVAL", "10s"))
+	if err != nil {
+		log.Fatalf("BROADCAST_BATCH_INTERVAL: %v", err)
+	}
+	broadcastSvc := service.NewBroadcastService(eventRepo, repository.NewBroadcastRepository(pool), jobRepo, notifier,
+		service.BroadcastConfig{
+			BatchSize:     batchSize,
+			BatchInterval: batchInterval,
+			BaseURL:       baseURL,
+		})
+	broadcastHandler := handler.NewBroadcastHandler(broadcastSvc)
+	runner.Handle(service.BroadcastSendJob, broadcastSvc.HandleSendJob)
+	if err := runner.Schedule("reminders", "* * * * *", "reminders.queue", nil); err != nil {
+		log.Fatalf("jobs: %v", err)
+	}
+	if err := runner.Schedule("ballots", "* * * * *", "ballots.draw", nil); err != nil {
+		log.Fatalf("jobs: %v", err)
+	}
+	if err := runner.Schedule("orders", "* * * * *", "orders.expire", nil); err != nil {
+		log.Fatalf("jobs: %v", err)
+	}
+	if err := runner.Schedule("refunds", "* * * * *", "refunds.process", nil); err != nil {
+		log.Fatalf("jobs: %v", err)
+	}
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		runner.Run(workerCtx)
+	}()
+	log.Printf("✓ Job runner started (%d workers, reminders %s before start)", jobWorkers, reminderLead)
 
-	// ── 3. Build the router ───────────────────────────────────────────────
+	// ── 4. Build the router ───────────────────────────────────────────────
 	r := chi.NewRouter()
 
 	// Global middleware stack
@@ -55,8 +187,103 @@ func main() {
 		r.Post("/", eventHandler.CreateEvent)
 		r.Get("/", eventHandler.ListEvents)
 		r.Get("/{id}", eventHandler.GetEvent)
+		r.Patch("/{id}", eventHandler.UpdateEvent)
 		r.Post("/{id}/register", eventHandler.Register)
+		r.Post("/{id}/register/group", eventHandler.RegisterGroup)
 		r.Get("/{id}/registrations", eventHandler.ListRegistrations)
+		r.Get("/{id}/registrations/export", eventHandler.ExportRegistrations)
+		r.Get("/{id}/questions", eventHandler.GetQuestions)
+		r.Put("/{id}/questions", eventHandler.SetQuestions)
+		r.Delete("/{id}/registrations/{regID}", eventHandler.CancelRegistration)
+		r.Post("/{id}/registrations/{regID}/check-in", eventHandler.CheckIn)
+		r.Post("/{id}/registrations/{regID}/transfer", eventHandler.TransferTicket)
+		r.Get("/{id}/transfers", eventHandler.ListTransfers)
+		r.Get("/{id}/orders", eventHandler.ListOrders)
+		r.Get("/{id}/refunds", eventHandler.ListRefunds)
+		r.Get("/{id}/refund-policy", eventHandler.GetRefundPolicy)
+		r.Put("/{id}/refund-policy", eventHandler.SetRefundPolicy)
+		r.Get("/{id}/applications", eventHandler.ListApplications)
+		r.Post("/{id}/applications/{appID}/approve", eventHandler.ApproveApplication)
+		r.Post("/{id}/applications/{appID}/reject", eventHandler.RejectApplication)
+		r.Put("/{id}/ballot", eventHandler.SetBallot)
+		r.Get("/{id}/ballot", eventHandler.GetBallot)
+		r.Post("/{id}/ballot/draw", eventHandler.DrawBallot)
+		r.Post("/{id}/invites", eventHandler.CreateInvites)
+		r.Get("/{id}/invites", eventHandler.ListInvites)
+		r.Delete("/{id}/invites/{code}", eventHandler.RevokeInvite)
+		r.Get("/{id}/eligibility", eventHandler.GetEligibility)
+		r.Put("/{id}/eligibility", eventHandler.SetEligibility)
+		r.Get("/{id}/phases", eventHandler.GetSalePhases)
+		r.Put("/{id}/phases", eventHandler.SetSalePhases)
+		r.Get("/{id}/allocations", eventHandler.ListAllocations)
+		r.Put("/{id}/allocations/{name}", eventHandler.SetAllocation)
+		r.Delete("/{id}/allocations/{name}", eventHandler.DeleteAllocation)
+		r.Post("/{id}/allocations/{name}/comps", eventHandler.IssueComp)
+		r.Get("/{id}/seats", eventHandler.GetSeats)
+
+		// Sessions
+		r.Post("/{id}/sessions", sessionHandler.CreateSession)
+		r.Get("/{id}/sessions", sessionHandler.ListSessions)
+		r.Get("/{id}/registrations/{regID}/sessions", sessionHandler.ListPicks)
+		r.Post("/{id}/registrations/{regID}/sessions", sessionHandler.PickSession)
+		r.Delete("/{id}/registrations/{regID}/sessions/{sessionID}", sessionHandler.DropSession)
+
+		// Notification templates
+		r.Get("/{id}/templates", templateHandler.ListTemplates)
+		r.Put("/{id}/templates/{kind}", templateHandler.SaveTemplate)
+		r.Delete("/{id}/templates/{kind}", templateHandler.ResetTemplate)
+		r.Get("/{id}/templates/{kind}/preview", templateHandler.PreviewTemplate)
+		r.Post("/{id}/templates/{kind}/preview", templateHandler.PreviewTemplate)
+
+		// Broadcast messages
+		r.Post("/{id}/messages", broadcastHandler.SendBroadcast)
+		r.Get("/{id}/messages", broadcastHandler.ListBroadcasts)
+		r.Get("/{id}/messages/{msgID}/recipients", broadcastHandler.ListRecipients)
+	})
+
+	r.Post("/registrations/bundle", eventHandler.RegisterBundle)
+
+	// Paid tickets: orders, refunds, and the payment provider's callbacks
+	r.Get("/orders/{id}", eventHandler.GetOrder)
+	r.Post("/refunds/{id}/retry", eventHandler.RetryRefund)
+	r.Post("/payments/callback", eventHandler.PaymentCallback)
+	if simulatePayments {
+		r.Post("/payments/fake/{paymentID}/{status}", eventHandler.SimulatePayment)
+	}
+
+	// Unsubscribe links in broadcast emails
+	r.Get("/unsubscribe/{token}", broadcastHandler.Unsubscribe)
+	r.Post("/unsubscribe/{token}", broadcastHandler.Unsubscribe)
+
+	r.Get("/tags", tagHandler.ListTags)
+
+	r.Route("/series", func(r chi.Router) {
+		r.Post("/", seriesHandler.CreateSeries)
+		r.Get("/{id}", seriesHandler.GetSeries)
+		r.Patch("/{id}/occurrences/{eventID}", seriesHandler.UpdateOccurrences)
+		r.Delete("/{id}/occurrences/{eventID}", seriesHandler.DeleteOccurrences)
+	})
+
+	r.Route("/venues", func(r chi.Router) {
+		r.Post("/", venueHandler.CreateVenue)
+		r.Get("/", venueHandler.ListVenues)
+		r.Get("/{id}", venueHandler.GetVenue)
+		r.Get("/{id}/seats", venueHandler.GetSeatMap)
+		r.Put("/{id}/seats", venueHandler.SetSeatMap)
+	})
+
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Post("/", webhookHandler.CreateWebhook)
+		r.Get("/", webhookHandler.ListWebhooks)
+		r.Delete("/{id}", webhookHandler.DeleteWebhook)
+		r.Get("/{id}/deliveries", webhookHandler.ListDeliveries)
+		r.Post("/deliveries/{deliveryID}/redeliver", webhookHandler.Redeliver)
+	})
+
+	r.Route("/jobs", func(r chi.Router) {
+		r.Get("/", jobHandler.ListJobs)
+		r.Get("/{id}", jobHandler.GetJob)
+		r.Post("/{id}/retry", jobHandler.RetryJob)
 	})
 
 	// Static HTML – serve the web/ directory at the root.
@@ -64,7 +291,7 @@ func main() {
 	webFS := http.Dir("./web")
 	r.Handle("/*", http.FileServer(webFS))
 
-	// ── 4. Start server with graceful shutdown ────────────────────────────
+	// ── 5. Start server with graceful shutdown ────────────────────────────
 	port := getEnv("PORT", "8080")
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", port),
@@ -94,6 +321,22 @@ func main() {
 		log.Fatalf("graceful shutdown failed: %v", err)
 	}
 	log.Println("server stopped")
+
+	// Stop claiming new work and drain what is in flight.  Anything still
+	// running when the timeout hits keeps its lease and is retried by the
+	// next process once the lease expires.
+	stopWorkers()
+	drained := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		log.Println("workers stopped")
+	case <-time.After(30 * time.Second):
+		log.Println("worker drain timed out; unfinished jobs will be retried")
+	}
 }
 
 func getEnv(key, fallback string) string {
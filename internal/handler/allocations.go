@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// ListAllocations handles GET /events/{id}/allocations
+// Returns the event's allocations with the comps each has issued.
+func (h *EventHandler) ListAllocations(w http.ResponseWriter, r *http.Request) {
+	allocs, err := h.svc.ListAllocations(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list allocations")
+		return
+	}
+	if allocs == nil {
+		allocs = []model.Allocation{}
+	}
+	writeJSON(w, http.StatusOK, allocs)
+}
+
+// SetAllocation handles PUT /events/{id}/allocations/{name}
+// Holds the given number of seats back from public sale under name.
+func (h *EventHandler) SetAllocation(w http.ResponseWriter, r *http.Request) {
+	var req model.SetAllocationRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	alloc, err := h.svc.SetAllocation(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "name"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "event not found")
+		case errors.Is(err, repository.ErrAllocationTooLarge),
+			errors.Is(err, repository.ErrAllocationBelowIssued):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, alloc)
+}
+
+// DeleteAllocation handles DELETE /events/{id}/allocations/{name}
+// Releases the allocation's unissued seats to the public.
+func (h *EventHandler) DeleteAllocation(w http.ResponseWriter, r *http.Request) {
+	err := h.svc.DeleteAllocation(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "name"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "allocation not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to delete allocation")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IssueComp handles POST /events/{id}/allocations/{name}/comps
+// Registers a guest free of charge from the allocation's seats.
+func (h *EventHandler) IssueComp(w http.ResponseWriter, r *http.Request) {
+	var req model.CompRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	reg, err := h.svc.IssueComp(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "name"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "allocation not found")
+		case errors.Is(err, repository.ErrAlreadyRegistered):
+			writeError(w, http.StatusConflict, "email already registered for this event")
+		case errors.Is(err, repository.ErrAllocationExhausted),
+			errors.Is(err, repository.ErrEventFull),
+			errors.Is(err, repository.ErrSeatTaken):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, reg)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// ListApplications handles GET /events/{id}/applications?status=
+// Returns the event's applications, oldest first.
+func (h *EventHandler) ListApplications(w http.ResponseWriter, r *http.Request) {
+	apps, err := h.svc.ListApplications(r.Context(), chi.URLParam(r, "id"), r.URL.Query().Get("status"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if apps == nil {
+		apps = []model.Application{}
+	}
+	writeJSON(w, http.StatusOK, apps)
+}
+
+// ApproveApplication handles POST /events/{id}/applications/{appID}/approve
+// Books the applicant a seat; 409 if the event filled up in the meantime.
+func (h *EventHandler) ApproveApplication(w http.ResponseWriter, r *http.Request) {
+	app, err := h.svc.ApproveApplication(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "appID"))
+	if err != nil {
+		writeApplicationError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, app)
+}
+
+// RejectApplication handles POST /events/{id}/applications/{appID}/reject
+// Declines the application and emails the applicant.
+func (h *EventHandler) RejectApplication(w http.ResponseWriter, r *http.Request) {
+	app, err := h.svc.RejectApplication(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "appID"))
+	if err != nil {
+		writeApplicationError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, app)
+}
+
+func writeApplicationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		writeError(w, http.StatusNotFound, "application not found")
+	case errors.Is(err, repository.ErrEventFull):
+		writeError(w, http.StatusConflict, "event is fully booked")
+	case errors.Is(err, repository.ErrApplicationDecided),
+		errors.Is(err, repository.ErrAlreadyRegistered),
+		errors.Is(err, repository.ErrSeatRequired):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to decide application")
+	}
+}
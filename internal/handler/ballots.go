@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// SetBallot handles PUT /events/{id}/ballot
+// Puts the event into ballot mode with an entry window, or moves the window
+// before the draw.
+func (h *EventHandler) SetBallot(w http.ResponseWriter, r *http.Request) {
+	var req model.SetBallotRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	ballot, err := h.svc.SetBallot(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "event not found")
+		case errors.Is(err, repository.ErrBallotDrawn),
+			errors.Is(err, repository.ErrBallotUnavailable):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, ballot)
+}
+
+// GetBallot handles GET /events/{id}/ballot
+// Returns the entry window and seed hash; after the draw also the seed and
+// every entry's rank, ticket and outcome.
+func (h *EventHandler) GetBallot(w http.ResponseWriter, r *http.Request) {
+	ballot, err := h.svc.GetBallot(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "ballot not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get ballot")
+		return
+	}
+	writeJSON(w, http.StatusOK, ballot)
+}
+
+// DrawBallot handles POST /events/{id}/ballot/draw
+// Draws a closed ballot now instead of waiting for the scheduled job.
+func (h *EventHandler) DrawBallot(w http.ResponseWriter, r *http.Request) {
+	ballot, err := h.svc.DrawBallot(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "ballot not found")
+		case errors.Is(err, repository.ErrBallotDrawn),
+			errors.Is(err, repository.ErrBallotNotClosed):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to draw ballot")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, ballot)
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// BroadcastHandler holds HTTP handlers for organizer broadcasts and the
+// attendee-facing unsubscribe page.
+type BroadcastHandler struct {
+	svc *service.BroadcastService
+}
+
+// NewBroadcastHandler constructs a BroadcastHandler.
+func NewBroadcastHandler(svc *service.BroadcastService) *BroadcastHandler {
+	return &BroadcastHandler{svc: svc}
+}
+
+// SendBroadcast handles POST /events/{id}/messages
+// Queues a message to the chosen audience and returns 202 immediately;
+// batches go out in the background.
+func (h *BroadcastHandler) SendBroadcast(w http.ResponseWriter, r *http.Request) {
+	var req model.SendBroadcastRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	msg, err := h.svc.SendBroadcast(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, msg)
+}
+
+// ListBroadcasts handles GET /events/{id}/messages
+// Returns the event's messages with per-status recipient counts.
+func (h *BroadcastHandler) ListBroadcasts(w http.ResponseWriter, r *http.Request) {
+	msgs, err := h.svc.ListMessages(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list messages")
+		return
+	}
+	if msgs == nil {
+		msgs = []model.BroadcastMessage{}
+	}
+	writeJSON(w, http.StatusOK, msgs)
+}
+
+// ListRecipients handles GET /events/{id}/messages/{msgID}/recipients
+// Returns the delivery status of the message for every recipient.
+func (h *BroadcastHandler) ListRecipients(w http.ResponseWriter, r *http.Request) {
+	recipients, err := h.svc.ListRecipients(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "msgID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "message not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list recipients")
+		return
+	}
+	if recipients == nil {
+		recipients = []model.BroadcastRecipient{}
+	}
+	writeJSON(w, http.StatusOK, recipients)
+}
+
+// unsubscribePage is shown by both unsubscribe routes.  The GET only asks for
+// confirmation, so link scanners that prefetch URLs cannot unsubscribe anyone.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8"/>
+  <meta name="viewport" content="width=device-width, initial-scale=1.0"/>
+  <title>EventBooking – Unsubscribe</title>
+  <link rel="stylesheet" href="/static/styles.css"/>
+</head>
+<body>
+
+<header>
+  <h1>🎟 EventBooking</h1>
+</header>
+
+<div class="container">
+  <div class="card">
+  {{- if .Done}}
+    <p class="page-title">You're unsubscribed</p>
+    <p class="page-sub">{{.Email}} will no longer receive messages about <strong>{{.Event}}</strong>.</p>
+  {{- else}}
+    <p class="page-title">Unsubscribe</p>
+    <p class="page-sub">Stop sending messages about <strong>{{.Event}}</strong> to {{.Email}}?</p>
+    <form method="post">
+      <button type="submit" class="btn btn-primary">Unsubscribe</button>
+    </form>
+  {{- end}}
+  </div>
+</div>
+
+</body>
+</html>
+`))
+
+// Unsubscribe handles GET and POST /unsubscribe/{token}
+// GET renders a confirmation page; POST records the opt-out.
+func (h *BroadcastHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	event, email, err := h.svc.LookupUnsubscribe(r.Context(), token)
+	if err == nil && r.Method == http.MethodPost {
+		err = h.svc.Unsubscribe(r.Context(), token)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			http.Error(w, "This unsubscribe link is not valid.", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Something went wrong. Please try again later.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	data := struct {
+		Event, Email string
+		Done         bool
+	}{event, email, r.Method == http.MethodPost}
+	if err := unsubscribePage.Execute(w, data); err != nil {
+		log.Printf("unsubscribe page: %v", err)
+	}
+}
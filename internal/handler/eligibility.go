@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetEligibility handles GET /events/{id}/eligibility
+// Returns the event's eligibility rules.
+func (h *EventHandler) GetEligibility(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.svc.GetEligibility(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get eligibility rules")
+		return
+	}
+	writeJSON(w, http.StatusOK, rules)
+}
+
+// SetEligibility handles PUT /events/{id}/eligibility
+// Replaces the event's eligibility rules; {} admits everyone.
+func (h *EventHandler) SetEligibility(w http.ResponseWriter, r *http.Request) {
+	var rules model.EligibilityRules
+	if err := decodeJSON(r, &rules); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	saved, err := h.svc.SetEligibility(r.Context(), chi.URLParam(r, "id"), rules)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, saved)
+}
+
+// writeEligibilityError writes a failed eligibility check with its reasons.
+// It is 422 when only missing answers stand in the way, which the attendee
+// can fix by resubmitting, and 403 otherwise.
+func writeEligibilityError(w http.ResponseWriter, err error, notEligible *repository.EligibilityError) {
+	status := http.StatusUnprocessableEntity
+	for _, reason := range notEligible.Reasons {
+		if reason.Rule != model.EligibilityAnswerMissing {
+			status = http.StatusForbidden
+		}
+	}
+	writeJSON(w, status, struct {
+		Error   string                    `json:"error"`
+		Reasons []model.EligibilityReason `json:"reasons"`
+	}{err.Error(), notEligible.Reasons})
+}
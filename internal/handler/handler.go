@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
 	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
@@ -16,11 +19,15 @@ import (
 // EventHandler holds all HTTP handlers for the event booking API.
 type EventHandler struct {
 	svc *service.EventService
+
+	// legacyLists makes list endpoints called without limit or cursor return
+	// the old bare JSON array of every row instead of a page envelope.
+	legacyLists bool
 }
 
 // NewEventHandler constructs an EventHandler.
-func NewEventHandler(svc *service.EventService) *EventHandler {
-	return &EventHandler{svc: svc}
+func NewEventHandler(svc *service.EventService, legacyLists bool) *EventHandler {
+	return &EventHandler{svc: svc, legacyLists: legacyLists}
 }
 
 // ─── Helper utilities ─────────────────────────────────────────────────────────
@@ -42,6 +49,84 @@ func decodeJSON(r *http.Request, dst any) error {
 	return dec.Decode(dst)
 }
 
+// pageRequest reads the limit and cursor query parameters.  The boolean
+// reports whether either was given.
+func pageRequest(r *http.Request) (model.PageRequest, bool, error) {
+	q := r.URL.Query()
+	page := model.PageRequest{Cursor: q.Get("cursor")}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return page, true, errors.New("limit must be a positive integer")
+		}
+		page.Limit = n
+	}
+	return page, q.Has("limit") || q.Has("cursor"), nil
+}
+
+// eventSearch reads the search, filter and sort query parameters of
+// GET /events.  Dates are RFC 3339 timestamps or YYYY-MM-DD days; a day in
+// "to" includes the whole day.
+func eventSearch(r *http.Request) (model.EventSearch, error) {
+	q := r.URL.Query()
+	search := model.EventSearch{
+		Query:        q.Get("q"),
+		Availability: q.Get("availability"),
+		Sort:         q.Get("sort"),
+	}
+	// tag may repeat and may hold a comma-separated list.
+	for _, v := range q["tag"] {
+		for t := range strings.SplitSeq(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				search.Tags = append(search.Tags, t)
+			}
+		}
+	}
+	if v := q.Get("near"); v != "" {
+		lat, lng, ok := strings.Cut(v, ",")
+		la, errLat := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+		ln, errLng := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+		if !ok || errLat != nil || errLng != nil {
+			return search, errors.New("near must be latitude,longitude")
+		}
+		search.Near = &model.GeoPoint{Latitude: la, Longitude: ln}
+	}
+	if v := q.Get("radius_km"); v != "" {
+		km, err := strconv.ParseFloat(v, 64)
+		if err != nil || km <= 0 {
+			return search, errors.New("radius_km must be a positive number")
+		}
+		search.RadiusKM = km
+	}
+	if v := q.Get("from"); v != "" {
+		t, _, err := parseDateParam(v)
+		if err != nil {
+			return search, errors.New("from must be an RFC 3339 time or a YYYY-MM-DD date")
+		}
+		search.StartsFrom = &t
+	}
+	if v := q.Get("to"); v != "" {
+		t, dateOnly, err := parseDateParam(v)
+		if err != nil {
+			return search, errors.New("to must be an RFC 3339 time or a YYYY-MM-DD date")
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		search.StartsBefore = &t
+	}
+	return search, nil
+}
+
+// parseDateParam parses an RFC 3339 time or a YYYY-MM-DD date (midnight UTC).
+func parseDateParam(v string) (t time.Time, dateOnly bool, err error) {
+	if t, err = time.Parse(time.DateOnly, v); err == nil {
+		return t, true, nil
+	}
+	t, err = time.Parse(time.RFC3339, v)
+	return t.UTC(), false, err
+}
+
 // ─── Handlers ─────────────────────────────────────────────────────────────────
 
 // CreateEvent handles POST /events
@@ -62,18 +147,43 @@ func (h *EventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, event)
 }
 
-// ListEvents handles GET /events
-// Returns a JSON array of all events.
+// ListEvents handles GET /events?q=&availability=&from=&to=&tag=&near=&radius_km=&sort=&limit=&cursor=
+// Returns a page of matching events as {"items": [...], "next_cursor": "..."}.
+// In legacy mode a request without any parameters gets a bare array of all events.
 func (h *EventHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
-	events, err := h.svc.ListEvents(r.Context())
+	page, paged, err := pageRequest(r)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to list events")
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	search, err := eventSearch(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if h.legacyLists && !paged && search.IsZero() {
+		events, err := h.svc.ListAllEvents(r.Context())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to list events")
+			return
+		}
+		// Return an empty array rather than null for better client compatibility.
+		if events == nil {
+			events = []model.Event{}
+		}
+		writeJSON(w, http.StatusOK, events)
 		return
 	}
 
-	// Return an empty array rather than null for better client compatibility.
-	if events == nil {
-		events = []model.Event{}
+	events, err := h.svc.ListEvents(r.Context(), search, page)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidQuery) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list events")
+		return
 	}
 
 	writeJSON(w, http.StatusOK, events)
@@ -97,6 +207,37 @@ func (h *EventHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, event)
 }
 
+// UpdateEvent handles PATCH /events/{id}
+// Applies a partial update; omitted fields are left unchanged.
+func (h *EventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req model.UpdateEventRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	event, err := h.svc.UpdateEvent(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "event not found")
+		case errors.Is(err, repository.ErrCapacityBelowBooked),
+			errors.Is(err, repository.ErrSeatsAssigned),
+			errors.Is(err, repository.ErrSeatingConflict),
+			errors.Is(err, repository.ErrPricingConflict),
+			errors.Is(err, repository.ErrNotEnoughSeats):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, event)
+}
+
 // Register handles POST /events/{id}/register
 // Performs a concurrency-safe registration for the specified event.
 func (h *EventHandler) Register(w http.ResponseWriter, r *http.Request) {
@@ -108,44 +249,214 @@ func (h *EventHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reg, err := h.svc.Register(r.Context(), id, req)
+	res, err := h.svc.Register(r.Context(), id, req)
 	if err != nil {
+		var notEligible *repository.EligibilityError
 		switch {
+		case errors.As(err, &notEligible):
+			writeEligibilityError(w, err, notEligible)
 		case errors.Is(err, repository.ErrNotFound):
 			writeError(w, http.StatusNotFound, "event not found")
 		case errors.Is(err, repository.ErrEventFull):
 			writeError(w, http.StatusConflict, "event is fully booked")
 		case errors.Is(err, repository.ErrAlreadyRegistered):
 			writeError(w, http.StatusConflict, "you are already registered for this event")
+		case errors.Is(err, repository.ErrAlreadyApplied):
+			writeError(w, http.StatusConflict, "you have already applied for this event")
+		case errors.Is(err, repository.ErrAlreadyEntered):
+			writeError(w, http.StatusConflict, "you have already entered the ballot for this event")
+		case errors.Is(err, repository.ErrOrderPending):
+			writeError(w, http.StatusConflict, "you already have an order awaiting payment for this event")
+		case errors.Is(err, repository.ErrBallotNotOpen):
+			writeError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, repository.ErrInviteRequired),
+			errors.Is(err, repository.ErrInvalidInvite),
+			errors.Is(err, repository.ErrAccessCodeRequired):
+			writeError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, repository.ErrNotOnSale),
+			errors.Is(err, repository.ErrSeatTaken):
+			writeError(w, http.StatusConflict, err.Error())
 		default:
 			writeError(w, http.StatusBadRequest, err.Error())
 		}
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, reg)
+	// Approval and ballot events accept a request that is decided later, and
+	// paid events an order that is booked once paid.
+	switch {
+	case res.Application != nil:
+		writeJSON(w, http.StatusAccepted, res.Application)
+	case res.BallotEntry != nil:
+		writeJSON(w, http.StatusAccepted, res.BallotEntry)
+	case res.Order != nil:
+		writeJSON(w, http.StatusAccepted, res.Order)
+	default:
+		writeJSON(w, http.StatusCreated, res.Registration)
+	}
 }
 
-// ListRegistrations handles GET /events/{id}/registrations
-// Returns all registrations for a given event.
+// RegisterGroup handles POST /events/{id}/register/group
+// Books a list of attendees (email and name) together: all of them under one
+// row lock, or none.  A duplicate rejection lists each attendee already
+// registered.
+func (h *EventHandler) RegisterGroup(w http.ResponseWriter, r *http.Request) {
+	var req model.GroupRegisterRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	group, err := h.svc.RegisterGroup(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		var (
+			dup         *repository.GroupConflictError
+			notEligible *repository.EligibilityError
+		)
+		switch {
+		case errors.As(err, &notEligible):
+			writeEligibilityError(w, err, notEligible)
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "event not found")
+		case errors.As(err, &dup):
+			writeJSON(w, http.StatusConflict, struct {
+				Error     string                `json:"error"`
+				Conflicts []model.GroupConflict `json:"conflicts"`
+			}{"some attendees are already registered for this event", dup.Conflicts})
+		case errors.Is(err, repository.ErrEventFull):
+			writeError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, repository.ErrApprovalRequired):
+			writeError(w, http.StatusConflict, "event requires approval; attendees must apply individually")
+		case errors.Is(err, repository.ErrBallotOpen):
+			writeError(w, http.StatusConflict, "event is allocated by ballot; attendees must enter individually")
+		case errors.Is(err, repository.ErrPaymentRequired):
+			writeError(w, http.StatusConflict, "event sells paid tickets; attendees must order individually")
+		case errors.Is(err, repository.ErrInviteRequired),
+			errors.Is(err, repository.ErrInvalidInvite),
+			errors.Is(err, repository.ErrAccessCodeRequired):
+			writeError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, repository.ErrNotOnSale),
+			errors.Is(err, repository.ErrSeatTaken):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, group)
+}
+
+// RegisterBundle handles POST /registrations/bundle
+// Registers one email for several events in one transaction: every event is
+// booked or none is, and a rejection lists each event that was missing,
+// full or already booked.
+func (h *EventHandler) RegisterBundle(w http.ResponseWriter, r *http.Request) {
+	var req model.BundleRegisterRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	regs, err := h.svc.RegisterBundle(r.Context(), req)
+	if err != nil {
+		var rejected *repository.BundleError
+		if errors.As(err, &rejected) {
+			status := http.StatusConflict
+			for _, c := range rejected.Conflicts {
+				if c.Reason == model.BundleReasonNotFound {
+					status = http.StatusNotFound
+				}
+			}
+			writeJSON(w, status, struct {
+				Error     string                 `json:"error"`
+				Conflicts []model.BundleConflict `json:"conflicts"`
+			}{repository.ErrBundleRejected.Error(), rejected.Conflicts})
+			return
+		}
+		var notEligible *repository.EligibilityError
+		if errors.As(err, &notEligible) {
+			writeEligibilityError(w, err, notEligible)
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, regs)
+}
+
+// ListRegistrations handles GET /events/{id}/registrations?limit=&cursor=
+// Returns a page of the event's registrations, oldest first, in the same
+// envelope as ListEvents (or a bare array in legacy mode).
 func (h *EventHandler) ListRegistrations(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	page, paged, err := pageRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	regs, err := h.svc.ListRegistrations(r.Context(), id)
+	var result any
+	if h.legacyLists && !paged {
+		regs, listErr := h.svc.ListAllRegistrations(r.Context(), id)
+		if regs == nil {
+			regs = []model.Registration{}
+		}
+		result, err = regs, listErr
+	} else {
+		result, err = h.svc.ListRegistrations(r.Context(), id, page)
+	}
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
 			writeError(w, http.StatusNotFound, "event not found")
+		case errors.Is(err, repository.ErrInvalidQuery):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to list registrations")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// CancelRegistration handles DELETE /events/{id}/registrations/{regID}
+// Cancels the registration and releases its seat.  A paid registration's
+// refund is returned with 200; otherwise there is no content.
+func (h *EventHandler) CancelRegistration(w http.ResponseWriter, r *http.Request) {
+	_, refund, err := h.svc.CancelRegistration(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "regID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "registration not found")
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "failed to list registrations")
+		writeError(w, http.StatusInternalServerError, "failed to cancel registration")
+		return
+	}
+	if refund != nil {
+		writeJSON(w, http.StatusOK, refund)
 		return
 	}
 
-	if regs == nil {
-		regs = []model.Registration{}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckIn handles POST /events/{id}/registrations/{regID}/check-in
+// Marks the attendee as checked in; repeating it keeps the first time.
+func (h *EventHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	reg, err := h.svc.CheckIn(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "regID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "registration not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to check in")
+		return
 	}
 
-	writeJSON(w, http.StatusOK, regs)
+	writeJSON(w, http.StatusOK, reg)
 }
 
 // ─── Health check ─────────────────────────────────────────────────────────────
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateInvites handles POST /events/{id}/invites
+// Generates a batch of random invite codes, single-use unless max_uses says
+// otherwise.
+func (h *EventHandler) CreateInvites(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateInvitesRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	invites, err := h.svc.CreateInvites(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, invites)
+}
+
+// ListInvites handles GET /events/{id}/invites
+// Returns every invite code of the event with its uses, oldest first.
+func (h *EventHandler) ListInvites(w http.ResponseWriter, r *http.Request) {
+	invites, err := h.svc.ListInvites(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list invite codes")
+		return
+	}
+	if invites == nil {
+		invites = []model.InviteCode{}
+	}
+	writeJSON(w, http.StatusOK, invites)
+}
+
+// RevokeInvite handles DELETE /events/{id}/invites/{code}
+// Revokes an invite code; registrations already made with it are kept.
+func (h *EventHandler) RevokeInvite(w http.ResponseWriter, r *http.Request) {
+	invite, err := h.svc.RevokeInvite(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "code"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "invite code not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to revoke invite code")
+		return
+	}
+	writeJSON(w, http.StatusOK, invite)
+}
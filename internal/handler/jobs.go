@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// JobHandler holds HTTP handlers for background job status.
+type JobHandler struct {
+	svc *service.JobService
+}
+
+// NewJobHandler constructs a JobHandler.
+func NewJobHandler(svc *service.JobService) *JobHandler {
+	return &JobHandler{svc: svc}
+}
+
+// ListJobs handles GET /jobs?status=
+// Returns the most recent jobs, optionally filtered by status.
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.svc.ListJobs(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if jobs == nil {
+		jobs = []model.Job{}
+	}
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+// GetJob handles GET /jobs/{id}
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.svc.GetJob(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "job not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get job")
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// RetryJob handles POST /jobs/{id}/retry
+// Re-queues a failed job with a fresh attempt budget.
+func (h *JobHandler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.svc.RetryJob(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "job not found")
+		case errors.Is(err, repository.ErrJobNotRetryable):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to retry job")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/payment"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetOrder handles GET /orders/{id}
+// Returns an order, so a buyer can follow it from pending to fulfilled.
+func (h *EventHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	order, err := h.svc.GetOrder(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "order not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get order")
+		return
+	}
+	writeJSON(w, http.StatusOK, order)
+}
+
+// ListOrders handles GET /events/{id}/orders?status=
+// Returns the event's orders, oldest first, optionally only those in one
+// status; paid orders are those that could not be booked and are refunded.
+func (h *EventHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	orders, err := h.svc.ListOrders(r.Context(), chi.URLParam(r, "id"), r.URL.Query().Get("status"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if orders == nil {
+		orders = []model.Order{}
+	}
+	writeJSON(w, http.StatusOK, orders)
+}
+
+// PaymentCallback handles POST /payments/callback
+// Receives the payment provider's signed report of a payment's outcome and
+// settles its order.  Any 2xx tells the provider to stop redelivering.
+func (h *EventHandler) PaymentCallback(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	order, err := h.svc.HandlePaymentCallback(r.Context(), body, r.Header)
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, order)
+}
+
+// SimulatePayment handles POST /payments/fake/{paymentID}/{status}
+// Ends a fake payment as succeeded or failed, as the checkout page's
+// buttons do.  It is refused unless the fake provider takes payments.
+func (h *EventHandler) SimulatePayment(w http.ResponseWriter, r *http.Request) {
+	order, err := h.svc.SimulatePayment(r.Context(), chi.URLParam(r, "paymentID"), chi.URLParam(r, "status"))
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, order)
+}
+
+// writePaymentError maps an error from settling a payment to a response.
+func writePaymentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, payment.ErrInvalidCallback):
+		writeError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, repository.ErrNotFound):
+		writeError(w, http.StatusNotFound, "no order has this payment")
+	case errors.Is(err, payment.ErrSimulationUnavailable):
+		writeError(w, http.StatusNotFound, err.Error())
+	default:
+		writeError(w, http.StatusBadRequest, err.Error())
+	}
+}
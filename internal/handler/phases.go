@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetSalePhases handles GET /events/{id}/phases
+// Returns the event's sale phases in start order with the seats each sold.
+func (h *EventHandler) GetSalePhases(w http.ResponseWriter, r *http.Request) {
+	phases, err := h.svc.GetSalePhases(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get sale phases")
+		return
+	}
+	if phases == nil {
+		phases = []model.SalePhase{}
+	}
+	writeJSON(w, http.StatusOK, phases)
+}
+
+// SetSalePhases handles PUT /events/{id}/phases
+// Replaces the event's sale phases; [] puts it on sale at any time again.
+func (h *EventHandler) SetSalePhases(w http.ResponseWriter, r *http.Request) {
+	var phases []model.SalePhase
+	if err := decodeJSON(r, &phases); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	saved, err := h.svc.SetSalePhases(r.Context(), chi.URLParam(r, "id"), phases)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if saved == nil {
+		saved = []model.SalePhase{}
+	}
+	writeJSON(w, http.StatusOK, saved)
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetQuestions handles GET /events/{id}/questions
+// Returns the event's registration form in order.
+func (h *EventHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
+	questions, err := h.svc.GetQuestions(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get questions")
+		return
+	}
+	if questions == nil {
+		questions = []model.Question{}
+	}
+	writeJSON(w, http.StatusOK, questions)
+}
+
+// SetQuestions handles PUT /events/{id}/questions
+// Replaces the registration form with the given list of questions.
+func (h *EventHandler) SetQuestions(w http.ResponseWriter, r *http.Request) {
+	var questions []model.Question
+	if err := decodeJSON(r, &questions); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	questions, err := h.svc.SetQuestions(r.Context(), chi.URLParam(r, "id"), questions)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, questions)
+}
+
+// ExportRegistrations handles GET /events/{id}/registrations/export
+// Streams every registration as CSV, oldest first, with one column per
+// current question after the fixed attendee columns.
+func (h *EventHandler) ExportRegistrations(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	questions, err := h.svc.GetQuestions(r.Context(), id)
+	if err == nil {
+		var regs []model.Registration
+		if regs, err = h.svc.ListAllRegistrations(r.Context(), id); err == nil {
+			writeRegistrationsCSV(w, id, questions, regs)
+			return
+		}
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "event not found")
+		return
+	}
+	writeError(w, http.StatusInternalServerError, "failed to export registrations")
+}
+
+func writeRegistrationsCSV(w http.ResponseWriter, eventID string, questions []model.Question, regs []model.Registration) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="registrations-%s.csv"`, eventID))
+
+	out := csv.NewWriter(w)
+	header := []string{"registration_id", "email", "name", "group_id", "registered_at", "checked_in_at"}
+	for _, q := range questions {
+		header = append(header, q.Label)
+	}
+	_ = out.Write(header)
+
+	for _, reg := range regs {
+		row := []string{reg.ID, reg.UserEmail, reg.Name, "", reg.CreatedAt.UTC().Format(time.RFC3339), ""}
+		if reg.GroupID != nil {
+			row[3] = *reg.GroupID
+		}
+		if reg.CheckedInAt != nil {
+			row[5] = reg.CheckedInAt.UTC().Format(time.RFC3339)
+		}
+		for _, q := range questions {
+			row = append(row, formatAnswer(reg.Answers[q.ID]))
+		}
+		_ = out.Write(row)
+	}
+	out.Flush()
+}
+
+// formatAnswer renders a stored answer for a CSV cell: lists joined with
+// "; ", booleans as yes/no.
+func formatAnswer(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		if v {
+			return "yes"
+		}
+		return "no"
+	case []any:
+		parts := make([]string, len(v))
+		for i, p := range v {
+			parts[i] = formatAnswer(p)
+		}
+		return strings.Join(parts, "; ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetRefundPolicy handles GET /events/{id}/refund-policy
+// Returns the event's refund rules, most days before first.
+func (h *EventHandler) GetRefundPolicy(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.svc.GetRefundPolicy(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get refund policy")
+		return
+	}
+	if rules == nil {
+		rules = []model.RefundRule{}
+	}
+	writeJSON(w, http.StatusOK, rules)
+}
+
+// SetRefundPolicy handles PUT /events/{id}/refund-policy
+// Replaces the event's refund rules; [] refunds nothing on cancellation.
+func (h *EventHandler) SetRefundPolicy(w http.ResponseWriter, r *http.Request) {
+	var rules []model.RefundRule
+	if err := decodeJSON(r, &rules); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	saved, err := h.svc.SetRefundPolicy(r.Context(), chi.URLParam(r, "id"), rules)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if saved == nil {
+		saved = []model.RefundRule{}
+	}
+	writeJSON(w, http.StatusOK, saved)
+}
+
+// ListRefunds handles GET /events/{id}/refunds?status=
+// Returns the event's refunds, oldest first, optionally only those in one
+// status; failed refunds have run out of attempts and need a retry.
+func (h *EventHandler) ListRefunds(w http.ResponseWriter, r *http.Request) {
+	refunds, err := h.svc.ListRefunds(r.Context(), chi.URLParam(r, "id"), r.URL.Query().Get("status"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if refunds == nil {
+		refunds = []model.Refund{}
+	}
+	writeJSON(w, http.StatusOK, refunds)
+}
+
+// RetryRefund handles POST /refunds/{id}/retry
+// Attempts a failed refund again with a fresh attempt budget.
+func (h *EventHandler) RetryRefund(w http.ResponseWriter, r *http.Request) {
+	refund, err := h.svc.RetryRefund(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "refund not found")
+		case errors.Is(err, repository.ErrRefundNotRetryable):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to retry refund")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, refund)
+}
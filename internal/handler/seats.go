@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetSeatMap handles GET /venues/{id}/seats
+// Returns the venue's seat map: sections of rows of seats, with their ids.
+func (h *VenueHandler) GetSeatMap(w http.ResponseWriter, r *http.Request) {
+	m, err := h.svc.GetSeatMap(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "venue not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get seat map")
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
+
+// SetSeatMap handles PUT /venues/{id}/seats
+// Replaces the venue's seat map.  Seats kept under the same section, row
+// and label keep their ids; removing a seat a registration holds is a 409.
+func (h *VenueHandler) SetSeatMap(w http.ResponseWriter, r *http.Request) {
+	var m model.SeatMap
+	if err := decodeJSON(r, &m); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	saved, err := h.svc.SetSeatMap(r.Context(), chi.URLParam(r, "id"), m)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "venue not found")
+		case errors.Is(err, repository.ErrSeatInUse),
+			errors.Is(err, repository.ErrNotEnoughSeats):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, saved)
+}
+
+// GetSeats handles GET /events/{id}/seats
+// Returns the seat map of a reserved-seating event with each seat's status,
+// available or taken, for a seat picker.
+func (h *EventHandler) GetSeats(w http.ResponseWriter, r *http.Request) {
+	m, err := h.svc.GetSeats(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "event not found")
+		case errors.Is(err, repository.ErrNotReservedSeating):
+			writeError(w, http.StatusNotFound, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to get seats")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// SeriesHandler holds HTTP handlers for recurring event series.
+type SeriesHandler struct {
+	svc *service.SeriesService
+}
+
+// NewSeriesHandler constructs a SeriesHandler.
+func NewSeriesHandler(svc *service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{svc: svc}
+}
+
+// CreateSeries handles POST /series
+// Creates a series and generates all of its occurrences as events.
+func (h *SeriesHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateSeriesRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	series, err := h.svc.CreateSeries(r.Context(), req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, series)
+}
+
+// GetSeries handles GET /series/{id}
+// Returns the series template and rule with its occurrences in start order.
+func (h *SeriesHandler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	series, err := h.svc.GetSeries(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "series not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get series")
+		return
+	}
+	if series.Occurrences == nil {
+		series.Occurrences = []model.Event{}
+	}
+	writeJSON(w, http.StatusOK, series)
+}
+
+// UpdateOccurrences handles PATCH /series/{id}/occurrences/{eventID}?scope=this|future
+// Edits one occurrence, or it and all later ones (optionally with a new rule).
+func (h *SeriesHandler) UpdateOccurrences(w http.ResponseWriter, r *http.Request) {
+	var req model.UpdateSeriesRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	series, err := h.svc.UpdateOccurrences(r.Context(),
+		chi.URLParam(r, "id"), chi.URLParam(r, "eventID"), r.URL.Query().Get("scope"), req)
+	if err != nil {
+		writeSeriesError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, series)
+}
+
+// DeleteOccurrences handles DELETE /series/{id}/occurrences/{eventID}?scope=this|future
+// Deletes one occurrence, or it and all later ones, if none has registrations or orders.
+func (h *SeriesHandler) DeleteOccurrences(w http.ResponseWriter, r *http.Request) {
+	err := h.svc.DeleteOccurrences(r.Context(),
+		chi.URLParam(r, "id"), chi.URLParam(r, "eventID"), r.URL.Query().Get("scope"))
+	if err != nil {
+		writeSeriesError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeSeriesError maps occurrence edit and delete errors to responses.  A
+// conflict over booked occurrences lists them so the organizer can decide
+// what to do with their attendees.
+func writeSeriesError(w http.ResponseWriter, err error) {
+	var booked *repository.BookedOccurrencesError
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		writeError(w, http.StatusNotFound, "series occurrence not found")
+	case errors.As(err, &booked):
+		writeJSON(w, http.StatusConflict, struct {
+			Error       string        `json:"error"`
+			Occurrences []model.Event `json:"occurrences"`
+		}{repository.ErrOccurrencesBooked.Error(), booked.Occurrences})
+	case errors.Is(err, repository.ErrCapacityBelowBooked),
+		errors.Is(err, repository.ErrSeatsAssigned),
+		errors.Is(err, repository.ErrSeatingConflict),
+		errors.Is(err, repository.ErrPricingConflict),
+		errors.Is(err, repository.ErrNotEnoughSeats):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// SessionHandler holds HTTP handlers for event sessions.
+type SessionHandler struct {
+	svc *service.SessionService
+}
+
+// NewSessionHandler constructs a SessionHandler.
+func NewSessionHandler(svc *service.SessionService) *SessionHandler {
+	return &SessionHandler{svc: svc}
+}
+
+// CreateSession handles POST /events/{id}/sessions
+// Adds a session with its own time slot and capacity.
+func (h *SessionHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateSessionRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	session, err := h.svc.CreateSession(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, session)
+}
+
+// ListSessions handles GET /events/{id}/sessions
+func (h *SessionHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.svc.ListSessions(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list sessions")
+		return
+	}
+	if sessions == nil {
+		sessions = []model.Session{}
+	}
+	writeJSON(w, http.StatusOK, sessions)
+}
+
+// ListPicks handles GET /events/{id}/registrations/{regID}/sessions
+// Returns the attendee's agenda.
+func (h *SessionHandler) ListPicks(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.svc.ListPicks(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "regID"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list sessions")
+		return
+	}
+	if sessions == nil {
+		sessions = []model.Session{}
+	}
+	writeJSON(w, http.StatusOK, sessions)
+}
+
+// PickSession handles POST /events/{id}/registrations/{regID}/sessions
+// Books a seat in a session for an attendee registered for the event.
+func (h *SessionHandler) PickSession(w http.ResponseWriter, r *http.Request) {
+	var req model.PickSessionRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	session, err := h.svc.PickSession(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "regID"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "registration or session not found")
+		case errors.Is(err, repository.ErrSessionFull),
+			errors.Is(err, repository.ErrSessionAlreadyPicked),
+			errors.Is(err, repository.ErrSessionConflict):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, session)
+}
+
+// DropSession handles DELETE /events/{id}/registrations/{regID}/sessions/{sessionID}
+func (h *SessionHandler) DropSession(w http.ResponseWriter, r *http.Request) {
+	err := h.svc.DropSession(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "regID"), chi.URLParam(r, "sessionID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "session pick not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to drop session")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
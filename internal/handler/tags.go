@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/service"
+)
+
+// TagHandler holds HTTP handlers for event tags.
+type TagHandler struct {
+	svc *service.TagService
+}
+
+// NewTagHandler constructs a TagHandler.
+func NewTagHandler(svc *service.TagService) *TagHandler {
+	return &TagHandler{svc: svc}
+}
+
+// ListTags handles GET /tags
+// Returns every tag in use with its event count, most used first.
+func (h *TagHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.svc.ListTags(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list tags")
+		return
+	}
+	if tags == nil {
+		tags = []model.Tag{}
+	}
+	writeJSON(w, http.StatusOK, tags)
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// TemplateHandler holds HTTP handlers for per-event notification templates.
+type TemplateHandler struct {
+	svc *service.TemplateService
+}
+
+// NewTemplateHandler constructs a TemplateHandler.
+func NewTemplateHandler(svc *service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{svc: svc}
+}
+
+// ListTemplates handles GET /events/{id}/templates
+// Returns the effective template of every kind (override or default).
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.svc.ListTemplates(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list templates")
+		return
+	}
+	writeJSON(w, http.StatusOK, templates)
+}
+
+// SaveTemplate handles PUT /events/{id}/templates/{kind}
+// Validates and stores a template override for the event.
+func (h *TemplateHandler) SaveTemplate(w http.ResponseWriter, r *http.Request) {
+	var req model.SaveTemplateRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	tpl, err := h.svc.SaveTemplate(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "kind"), req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, tpl)
+}
+
+// ResetTemplate handles DELETE /events/{id}/templates/{kind}
+// Removes the override so the event uses the default template again.
+func (h *TemplateHandler) ResetTemplate(w http.ResponseWriter, r *http.Request) {
+	err := h.svc.ResetTemplate(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "kind"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PreviewTemplate handles GET and POST /events/{id}/templates/{kind}/preview
+// Renders the template for a sample registration. A POST body is treated as
+// an unsaved draft; GET renders the event's effective template.
+func (h *TemplateHandler) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	var draft *model.SaveTemplateRequest
+	if r.Method == http.MethodPost {
+		draft = &model.SaveTemplateRequest{}
+		if err := decodeJSON(r, draft); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	preview, err := h.svc.PreviewTemplate(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "kind"), draft)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, preview)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+// TransferTicket handles POST /events/{id}/registrations/{regID}/transfer
+// Moves the registration to a new attendee.  The current holder authorizes
+// it with their ticket code, which stops working; the new holder is emailed
+// a new one.
+func (h *EventHandler) TransferTicket(w http.ResponseWriter, r *http.Request) {
+	var req model.TransferRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	transfer, err := h.svc.TransferTicket(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "regID"), req)
+	if err != nil {
+		var notEligible *repository.EligibilityError
+		switch {
+		case errors.As(err, &notEligible):
+			writeEligibilityError(w, err, notEligible)
+		case errors.Is(err, repository.ErrNotFound):
+			writeError(w, http.StatusNotFound, "registration not found")
+		case errors.Is(err, repository.ErrInvalidTicket),
+			errors.Is(err, repository.ErrTransfersDisabled):
+			writeError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, repository.ErrAlreadyRegistered):
+			writeError(w, http.StatusConflict, "the new attendee is already registered for this event")
+		case errors.Is(err, repository.ErrTicketUsed):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, transfer)
+}
+
+// ListTransfers handles GET /events/{id}/transfers
+// Returns the event's ticket transfers, oldest first, including those of
+// registrations cancelled since.
+func (h *EventHandler) ListTransfers(w http.ResponseWriter, r *http.Request) {
+	transfers, err := h.svc.ListTransfers(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list transfers")
+		return
+	}
+	if transfers == nil {
+		transfers = []model.TicketTransfer{}
+	}
+	writeJSON(w, http.StatusOK, transfers)
+}
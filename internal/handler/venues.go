@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// VenueHandler holds HTTP handlers for venues.
+type VenueHandler struct {
+	svc *service.VenueService
+}
+
+// NewVenueHandler constructs a VenueHandler.
+func NewVenueHandler(svc *service.VenueService) *VenueHandler {
+	return &VenueHandler{svc: svc}
+}
+
+// CreateVenue handles POST /venues
+// Creates a venue with its address, coordinates and maximum capacity.
+func (h *VenueHandler) CreateVenue(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateVenueRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	venue, err := h.svc.CreateVenue(r.Context(), req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, venue)
+}
+
+// ListVenues handles GET /venues
+func (h *VenueHandler) ListVenues(w http.ResponseWriter, r *http.Request) {
+	venues, err := h.svc.ListVenues(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list venues")
+		return
+	}
+	if venues == nil {
+		venues = []model.Venue{}
+	}
+	writeJSON(w, http.StatusOK, venues)
+}
+
+// GetVenue handles GET /venues/{id}
+func (h *VenueHandler) GetVenue(w http.ResponseWriter, r *http.Request) {
+	venue, err := h.svc.GetVenue(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "venue not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to get venue")
+		return
+	}
+	writeJSON(w, http.StatusOK, venue)
+}
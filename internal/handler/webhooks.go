@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// WebhookHandler holds HTTP handlers for organizer webhooks.
+type WebhookHandler struct {
+	svc *service.WebhookService
+}
+
+// NewWebhookHandler constructs a WebhookHandler.
+func NewWebhookHandler(svc *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{svc: svc}
+}
+
+// CreateWebhook handles POST /webhooks
+// Registers an endpoint for one event (event_id) or all events (no event_id).
+// The response includes the signing secret, which is never shown again.
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateWebhookRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	endpoint, err := h.svc.CreateWebhook(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, endpoint)
+}
+
+// ListWebhooks handles GET /webhooks?event_id=
+// Returns registered endpoints, without secrets.
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	endpoints, err := h.svc.ListWebhooks(r.Context(), r.URL.Query().Get("event_id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list webhooks")
+		return
+	}
+	if endpoints == nil {
+		endpoints = []model.WebhookEndpoint{}
+	}
+	writeJSON(w, http.StatusOK, endpoints)
+}
+
+// DeleteWebhook handles DELETE /webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.DeleteWebhook(r.Context(), chi.URLParam(r, "id")); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "webhook not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to delete webhook")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /webhooks/{id}/deliveries
+// Returns the endpoint's most recent deliveries with status and last error.
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.svc.ListDeliveries(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "webhook not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list deliveries")
+		return
+	}
+	if deliveries == nil {
+		deliveries = []model.WebhookDelivery{}
+	}
+	writeJSON(w, http.StatusOK, deliveries)
+}
+
+// Redeliver handles POST /webhooks/deliveries/{deliveryID}/redeliver
+// Queues a new delivery of the same payload for immediate sending.
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	d, err := h.svc.Redeliver(r.Context(), chi.URLParam(r, "deliveryID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "delivery not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to redeliver")
+		return
+	}
+	writeJSON(w, http.StatusAccepted, d)
+}
@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	// 2024-01-15 is a Monday.
+	const from = "2024-01-15T10:07:30Z"
+
+	tests := []struct {
+		name string
+		spec string
+		from string
+		want string // "" means the schedule never fires
+	}{
+		{"every minute", "* * * * *", from, "2024-01-15T10:08:00Z"},
+		{"star step", "*/15 * * * *", from, "2024-01-15T10:15:00Z"},
+		{"start step", "5/15 * * * *", from, "2024-01-15T10:20:00Z"},
+		{"hour range", "0 9-17 * * *", from, "2024-01-15T11:00:00Z"},
+		{"stepped range", "0 9-17/4 * * *", from, "2024-01-15T13:00:00Z"},
+		{"list", "30 8,12,18 * * *", from, "2024-01-15T12:30:00Z"},
+		{"list and range", "0 1,20-22 * * *", from, "2024-01-15T20:00:00Z"},
+		{"first of month rolls over", "0 0 1 * *", from, "2024-02-01T00:00:00Z"},
+		{"day of week only", "0 0 * * 5", from, "2024-01-19T00:00:00Z"},
+		{"sunday", "0 0 * * 0", from, "2024-01-21T00:00:00Z"},
+		{"either day field, weekday first", "0 0 20 * 3", from, "2024-01-17T00:00:00Z"},
+		{"either day field, date first", "0 0 16 * 6", from, "2024-01-16T00:00:00Z"},
+		{"month end skips short months", "0 12 31 * *", "2024-01-31T13:00:00Z", "2024-03-31T12:00:00Z"},
+		{"minute rolls into next month", "* * * * *", "2024-01-31T23:59:00Z", "2024-02-01T00:00:00Z"},
+		{"year rollover", "0 0 1 1 *", from, "2025-01-01T00:00:00Z"},
+		{"leap day", "0 0 29 2 *", "2024-03-01T00:00:00Z", "2028-02-29T00:00:00Z"},
+		{"strictly after", "7 10 * * *", "2024-01-15T10:07:00Z", "2024-01-16T10:07:00Z"},
+		{"non-UTC input", "0 12 * * *", "2024-01-15T12:30:00+05:00", "2024-01-15T12:00:00Z"},
+		{"unsatisfiable", "0 0 31 2 *", from, ""},
+		{"hourly", "@hourly", from, "2024-01-15T11:00:00Z"},
+		{"daily", "@daily", from, "2024-01-16T00:00:00Z"},
+		{"weekly", "@weekly", from, "2024-01-21T00:00:00Z"},
+		{"monthly", "@monthly", from, "2024-02-01T00:00:00Z"},
+		{"every", "@every 30s", from, "2024-01-15T10:08:00Z"},
+		{"every aligned to epoch", "@every 1h", from, "2024-01-15T11:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q): %v", tt.spec, err)
+			}
+			got := s.Next(at(tt.from))
+			var want time.Time
+			if tt.want != "" {
+				want = at(tt.want)
+			}
+			if !got.Equal(want) {
+				t.Errorf("Next(%s) for %q = %v, want %v", tt.from, tt.spec, got, want)
+			}
+		})
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 0 *",
+		"* * * 13 *",
+		"* * * * 7",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1-x * * * *",
+		"1,,2 * * * *",
+		"@yearly",
+		"@every",
+		"@every soon",
+		"@every 500ms",
+	} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q): want an error", spec)
+		}
+	}
+}
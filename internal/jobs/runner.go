@@ -0,0 +1,203 @@
+// Package jobs runs background work from a Postgres-backed queue: a pool of
+// workers claims jobs with FOR UPDATE SKIP LOCKED, and a scheduler enqueues
+// recurring (cron-like) jobs.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// Runner tuning. A job's lease must outlast its longest expected run; a job
+// whose lease expires is assumed abandoned and handed to another worker.
+const (
+	idlePoll          = time.Second
+	scheduleTick      = 15 * time.Second
+	jobLease          = 5 * time.Minute
+	retryBase         = 10 * time.Second
+	retryCap          = 30 * time.Minute
+	defaultMaxAttempt = 5
+)
+
+// HandlerFunc executes one job. A returned error fails the attempt.
+type HandlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+type recurring struct {
+	name     string
+	spec     string
+	schedule Schedule
+	kind     string
+	payload  any
+	next     time.Time
+}
+
+// Runner owns the worker pool and the recurring-job scheduler.
+type Runner struct {
+	jobs      *repository.JobRepository
+	workers   int
+	handlers  map[string]HandlerFunc
+	recurring []*recurring
+}
+
+// NewRunner constructs a Runner with the given number of workers.
+func NewRunner(jobs *repository.JobRepository, workers int) *Runner {
+	return &Runner{jobs: jobs, workers: max(workers, 1), handlers: map[string]HandlerFunc{}}
+}
+
+// Handle registers the handler for a job kind. It must be called before Run.
+func (r *Runner) Handle(kind string, fn HandlerFunc) {
+	r.handlers[kind] = fn
+}
+
+// Schedule registers a recurring job: at every fire time of spec (see
+// ParseSchedule) a job of kind is enqueued with payload.  It must be called
+// before Run.
+func (r *Runner) Schedule(name, spec, kind string, payload any) error {
+	s, err := ParseSchedule(spec)
+	if err != nil {
+		return fmt.Errorf("schedule %s: %w", name, err)
+	}
+	r.recurring = append(r.recurring, &recurring{
+		name: name, spec: spec, schedule: s, kind: kind, payload: payload,
+	})
+	return nil
+}
+
+// Enqueue adds a one-off job to run at runAt.
+func (r *Runner) Enqueue(ctx context.Context, kind string, payload any, runAt time.Time) error {
+	_, err := r.jobs.Enqueue(ctx, kind, payload, runAt, defaultMaxAttempt, "")
+	return err
+}
+
+// Run starts the scheduler and the workers and blocks until ctx is cancelled
+// and every in-flight job has finished (graceful drain). Jobs keep running
+// with a context that is not cancelled on shutdown, so a SIGTERM never
+// interrupts a job halfway; the caller bounds the drain with its own timeout.
+func (r *Runner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.schedule(ctx)
+	}()
+
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.work(ctx)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// schedule enqueues recurring jobs as they come due.  Every instance runs a
+// scheduler; the unique key "<name>@<fire time>" makes sure only one of them
+// actually enqueues each occurrence.
+func (r *Runner) schedule(ctx context.Context) {
+	now := time.Now().UTC()
+	for _, rec := range r.recurring {
+		rec.next = rec.schedule.Next(now)
+	}
+
+	ticker := time.NewTicker(scheduleTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now().UTC()
+		for _, rec := range r.recurring {
+			if rec.next.IsZero() || now.Before(rec.next) {
+				continue
+			}
+			key := rec.name + "@" + rec.next.Format(time.RFC3339)
+			if _, err := r.jobs.Enqueue(ctx, rec.kind, rec.payload, rec.next, defaultMaxAttempt, key); err != nil {
+				log.Printf("jobs: schedule %s: %v", rec.name, err)
+				continue // try the same occurrence again next tick
+			}
+			// Skip occurrences missed while the process was down or slow;
+			// recurring jobs are expected to catch up on their own.
+			rec.next = rec.schedule.Next(now)
+		}
+	}
+}
+
+// work claims and runs jobs until ctx is cancelled.
+func (r *Runner) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := r.jobs.Claim(ctx, jobLease)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("jobs: %v", err)
+			}
+		}
+		if job == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(idlePoll):
+			}
+			continue
+		}
+		r.execute(context.WithoutCancel(ctx), job)
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job *model.Job) {
+	runErr := r.call(ctx, job)
+	if runErr == nil {
+		if err := r.jobs.Complete(ctx, job); err != nil {
+			log.Printf("jobs: %v", err)
+		}
+		return
+	}
+
+	var retryAt *time.Time
+	if job.Attempts < job.MaxAttempts {
+		t := time.Now().UTC().Add(backoff(job.Attempts))
+		retryAt = &t
+	}
+	log.Printf("jobs: %s %s (attempt %d/%d): %v", job.Kind, job.ID, job.Attempts, job.MaxAttempts, runErr)
+	if err := r.jobs.Fail(ctx, job, runErr, retryAt); err != nil {
+		log.Printf("jobs: %v", err)
+	}
+}
+
+// call runs the job's handler, turning a panic into an ordinary failure so
+// one bad job cannot take the worker down.
+func (r *Runner) call(ctx context.Context, job *model.Job) (err error) {
+	fn, ok := r.handlers[job.Kind]
+	if !ok {
+		return fmt.Errorf("no handler registered for job kind %q", job.Kind)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return fn(ctx, job.Payload)
+}
+
+// backoff returns the delay before retry number attempt (1-based).
+func backoff(attempt int) time.Duration {
+	d := retryBase
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= retryCap {
+			return retryCap
+		}
+	}
+	return d
+}
@@ -0,0 +1,25 @@
+package model
+
+// Allocation is a named block of an event's seats held back from public
+// sale, for example for speakers or sponsors.  Complimentary registrations
+// are issued against it.
+type Allocation struct {
+	Name      string `json:"name"`
+	Seats     int    `json:"seats"`
+	Issued    int    `json:"issued"`
+	Remaining int    `json:"remaining"`
+}
+
+// SetAllocationRequest is the payload for creating or resizing an
+// allocation.
+type SetAllocationRequest struct {
+	Seats int `json:"seats"`
+}
+
+// CompRequest is the payload for issuing a complimentary registration.
+type CompRequest struct {
+	UserEmail string  `json:"user_email"`
+	Name      string  `json:"name,omitempty"`
+	Answers   Answers `json:"answers,omitempty"`
+	SeatID    string  `json:"seat_id,omitempty"` // required at events with reserved seating
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Application states.  Only a pending application can be decided.
+const (
+	ApplicationPending  = "pending"
+	ApplicationApproved = "approved"
+	ApplicationRejected = "rejected"
+)
+
+// ApplicationStatuses lists the valid application states.
+var ApplicationStatuses = []string{ApplicationPending, ApplicationApproved, ApplicationRejected}
+
+// Application is a request to attend an event that requires approval.  It
+// holds no seat until it is approved, which creates RegistrationID.
+type Application struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	UserEmail      string     `json:"user_email"`
+	Name           string     `json:"name,omitempty"`
+	Answers        Answers    `json:"answers,omitempty"`
+	Status         string     `json:"status"`
+	RegistrationID *string    `json:"registration_id,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Ballot entry outcomes.
+const (
+	BallotEntered    = "entered"    // waiting for the draw
+	BallotWon        = "won"        // booked by the draw
+	BallotWaitlisted = "waitlisted" // drawn after the seats ran out
+	BallotPromoted   = "promoted"   // booked later from the waitlist
+)
+
+// Ballot is an event's entry window and, once drawn, its published draw.
+// Seed is empty until the draw; SeedHash commits to it beforehand.
+type Ballot struct {
+	EventID    string         `json:"event_id"`
+	OpensAt    time.Time      `json:"opens_at"`
+	ClosesAt   time.Time      `json:"closes_at"`
+	SeedHash   string         `json:"seed_hash"`
+	Seed       string         `json:"seed,omitempty"`
+	DrawnAt    *time.Time     `json:"drawn_at,omitempty"`
+	EntryCount int            `json:"entry_count"`
+	Results    []BallotResult `json:"results,omitempty"` // draw order, once drawn
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// IsOpen reports whether entries are accepted at t.
+func (b *Ballot) IsOpen(t time.Time) bool {
+	return b.DrawnAt == nil && !t.Before(b.OpensAt) && t.Before(b.ClosesAt)
+}
+
+// BallotEntry is one person's entry in an event's ballot.
+type BallotEntry struct {
+	ID             string    `json:"id"`
+	EventID        string    `json:"event_id"`
+	UserEmail      string    `json:"user_email"`
+	Name           string    `json:"name,omitempty"`
+	Answers        Answers   `json:"answers,omitempty"`
+	Outcome        string    `json:"outcome"`
+	DrawRank       *int      `json:"draw_rank,omitempty"`
+	RegistrationID *string   `json:"registration_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// BallotResult is one entry's line in the published draw.  It carries no
+// email: entrants find themselves by the entry id they were given.
+type BallotResult struct {
+	Rank    int    `json:"rank"`
+	EntryID string `json:"entry_id"`
+	Ticket  string `json:"ticket"`
+	Outcome string `json:"outcome"`
+}
+
+// SetBallotRequest is the payload for putting an event into ballot mode.
+type SetBallotRequest struct {
+	OpensAt  *time.Time `json:"opens_at"`
+	ClosesAt *time.Time `json:"closes_at"`
+}
+
+// BallotTicket is an entry's draw ticket: the hex HMAC-SHA256 of the entry
+// id keyed with the seed.  The draw orders entries by ticket, ascending
+// (entry id breaks ties), so anyone holding the published seed and entry
+// ids can recompute it.
+func BallotTicket(seed, entryID string) string {
+	mac := hmac.New(sha256.New, []byte(seed))
+	mac.Write([]byte(entryID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// BallotOrder returns entry ids in draw order under seed: by ticket,
+// ascending, with the entry id breaking ties.  The draw seats entries from
+// the front and waitlists the rest in the same order, so the result depends
+// only on the seed and the set of ids, never on the order they are listed.
+func BallotOrder(seed string, entryIDs []string) []string {
+	type ranked struct{ id, ticket string }
+	entries := make([]ranked, len(entryIDs))
+	for i, id := range entryIDs {
+		entries[i] = ranked{id, BallotTicket(seed, id)}
+	}
+	slices.SortFunc(entries, func(a, b ranked) int {
+		if c := strings.Compare(a.ticket, b.ticket); c != 0 {
+			return c
+		}
+		return strings.Compare(a.id, b.id)
+	})
+	order := make([]string, len(entries))
+	for i, e := range entries {
+		order[i] = e.id
+	}
+	return order
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestBallotOrder(t *testing.T) {
+	const seats = 3
+	ids := make([]string, 10)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("entry-%02d", i)
+	}
+	reversed := slices.Clone(ids)
+	slices.Reverse(reversed)
+
+	first := BallotOrder("published-seed", ids)
+	again := BallotOrder("published-seed", reversed)
+	if !slices.Equal(first, again) {
+		t.Fatalf("same seed, different order:\n%v\n%v", first, again)
+	}
+	if winners, waitlist := first[:seats], first[seats:]; !slices.Equal(winners, again[:seats]) || !slices.Equal(waitlist, again[seats:]) {
+		t.Errorf("same seed, different winners or waitlist")
+	}
+
+	sorted := slices.Clone(first)
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, ids) {
+		t.Errorf("order %v is not a permutation of the entries", first)
+	}
+	for i := 1; i < len(first); i++ {
+		if BallotTicket("published-seed", first[i-1]) > BallotTicket("published-seed", first[i]) {
+			t.Errorf("entries %d and %d are not in ticket order", i-1, i)
+		}
+	}
+
+	if other := BallotOrder("another-seed", ids); slices.Equal(other, first) {
+		t.Errorf("a different seed drew the same order %v", other)
+	}
+
+	// Pinned so that a change to the ticket scheme, which would make
+	// published draws unverifiable, fails loudly.
+	if got, want := BallotTicket("seed", "entry"), "d497339512b8ad78b61d3489e0b4119b8f4c0886d39cfb7f2629654cd180e3d8"; got != want {
+		t.Errorf("BallotTicket(seed, entry) = %s, want %s", got, want)
+	}
+}
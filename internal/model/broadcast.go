@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// Broadcast audiences: which registrants a message goes to.
+const (
+	AudienceAll        = "all"
+	AudienceConfirmed  = "confirmed"
+	AudienceCheckedIn  = "checked_in"
+	AudienceWaitlisted = "waitlisted"
+)
+
+// Broadcast message and recipient states.
+const (
+	BroadcastSending   = "sending"
+	BroadcastCompleted = "completed"
+
+	RecipientPending      = "pending"
+	RecipientSent         = "sent"
+	RecipientFailed       = "failed"
+	RecipientUnsubscribed = "unsubscribed"
+)
+
+// BroadcastMessage is an organizer-composed message to an event's registrants.
+type BroadcastMessage struct {
+	ID          string          `json:"id"`
+	EventID     string          `json:"event_id"`
+	Subject     string          `json:"subject"`
+	Body        string          `json:"body"`
+	Audience    string          `json:"audience"`
+	Status      string          `json:"status"`
+	Counts      BroadcastCounts `json:"counts"`
+	CreatedAt   time.Time       `json:"created_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// BroadcastCounts tallies a message's recipients by delivery status.
+type BroadcastCounts struct {
+	Pending      int `json:"pending"`
+	Sent         int `json:"sent"`
+	Failed       int `json:"failed"`
+	Unsubscribed int `json:"unsubscribed"`
+}
+
+// BroadcastRecipient is the delivery status of a message for one address.
+type BroadcastRecipient struct {
+	MessageID string     `json:"message_id"`
+	Email     string     `json:"email"`
+	Status    string     `json:"status"`
+	Attempts  int        `json:"attempts"`
+	LastError string     `json:"last_error,omitempty"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+
+	// UnsubscribeToken is only used to build the link in the outgoing email.
+	UnsubscribeToken string `json:"-"`
+}
+
+// SendBroadcastRequest is the payload for composing a broadcast message.
+type SendBroadcastRequest struct {
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+	Audience string `json:"audience"`
+}
@@ -0,0 +1,28 @@
+package model
+
+// Reasons a bundle registration was rejected for one of its events.
+const (
+	BundleReasonNotFound          = "not_found"
+	BundleReasonFull              = "full"
+	BundleReasonAlreadyRegistered = "already_registered"
+	BundleReasonRequiresApproval  = "requires_approval"
+	BundleReasonBallot            = "ballot"
+	BundleReasonInviteOnly        = "invite_only"
+	BundleReasonNotOnSale         = "not_on_sale"
+	BundleReasonReservedSeating   = "reserved_seating"
+	BundleReasonPaid              = "paid"
+)
+
+// BundleRegisterRequest is the payload for registering one email for several
+// events at once, all or nothing.
+type BundleRegisterRequest struct {
+	UserEmail string             `json:"user_email"`
+	EventIDs  []string           `json:"event_ids"`
+	Answers   map[string]Answers `json:"answers,omitempty"` // keyed by event id
+}
+
+// BundleConflict names an event that stopped a bundle registration and why.
+type BundleConflict struct {
+	EventID string `json:"event_id"`
+	Reason  string `json:"reason"`
+}
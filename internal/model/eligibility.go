@@ -0,0 +1,57 @@
+package model
+
+// Scopes of a per-email registration limit.
+const (
+	LimitScopeSeries = "series" // every occurrence of the event's series
+	LimitScopeTag    = "tag"    // every event carrying Tag
+)
+
+// LimitScopes lists the valid limit scopes.
+var LimitScopes = []string{LimitScopeSeries, LimitScopeTag}
+
+// EligibilityRules restrict who may register for an event.  The zero value
+// admits everyone.
+type EligibilityRules struct {
+	AllowedDomains  []string            `json:"allowed_domains,omitempty"` // if set, only these email domains
+	BlockedDomains  []string            `json:"blocked_domains,omitempty"`
+	Limits          []RegistrationLimit `json:"limits,omitempty"`
+	RequiredAnswers []RequiredAnswer    `json:"required_answers,omitempty"`
+}
+
+// IsZero reports whether the rules admit everyone.
+func (r EligibilityRules) IsZero() bool {
+	return len(r.AllowedDomains) == 0 && len(r.BlockedDomains) == 0 &&
+		len(r.Limits) == 0 && len(r.RequiredAnswers) == 0
+}
+
+// RegistrationLimit caps how many events of a series, or carrying a tag,
+// one email may be registered for.  The event being booked counts.
+type RegistrationLimit struct {
+	Scope string `json:"scope"`         // one of LimitScopes
+	Tag   string `json:"tag,omitempty"` // for LimitScopeTag
+	Max   int    `json:"max"`
+}
+
+// RequiredAnswer makes a question's answer a condition of registering.  With
+// Accepted empty any answer will do; otherwise the answer (or, for
+// multi-choice, one of the options picked) must be one of Accepted.
+// Boolean answers compare as "true" and "false".
+type RequiredAnswer struct {
+	QuestionID string   `json:"question_id"`
+	Accepted   []string `json:"accepted,omitempty"`
+}
+
+// Reasons a registration is not eligible.
+const (
+	EligibilityDomainNotAllowed  = "domain_not_allowed"
+	EligibilityDomainBlocked     = "domain_blocked"
+	EligibilityLimitReached      = "limit_reached"
+	EligibilityAnswerMissing     = "answer_missing"
+	EligibilityAnswerNotAccepted = "answer_not_accepted"
+)
+
+// EligibilityReason is one rule a registration failed.
+type EligibilityReason struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
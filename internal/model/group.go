@@ -0,0 +1,39 @@
+package model
+
+// DefaultMaxGroupSize is an event's max_group_size unless the organizer sets
+// another.
+const DefaultMaxGroupSize = 10
+
+// Reasons a group registration was rejected for one of its attendees.
+const (
+	GroupReasonAlreadyRegistered = "already_registered"
+)
+
+// GroupAttendee is one person in a group registration.
+type GroupAttendee struct {
+	Email   string  `json:"email"`
+	Name    string  `json:"name"`
+	Answers Answers `json:"answers,omitempty"`
+	SeatID  string  `json:"seat_id,omitempty"` // required at events with reserved seating
+}
+
+// GroupRegisterRequest is the payload for booking several attendees for one
+// event at once, all or nothing.
+type GroupRegisterRequest struct {
+	Attendees  []GroupAttendee `json:"attendees"`
+	InviteCode string          `json:"invite_code,omitempty"` // spends one use per attendee
+	AccessCode string          `json:"access_code,omitempty"` // unlocks a presale
+}
+
+// GroupRegistration is the outcome of a successful group registration.  The
+// registrations share GroupID.
+type GroupRegistration struct {
+	GroupID       string         `json:"group_id"`
+	Registrations []Registration `json:"registrations"`
+}
+
+// GroupConflict names an attendee that stopped a group registration and why.
+type GroupConflict struct {
+	Email  string `json:"email"`
+	Reason string `json:"reason"`
+}
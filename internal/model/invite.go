@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Event visibility settings.
+const (
+	VisibilityPublic     = "public"      // listed and open to anyone
+	VisibilityUnlisted   = "unlisted"    // reachable only through its link
+	VisibilityInviteOnly = "invite_only" // unlisted, and booking needs an invite code
+)
+
+// Visibilities lists the valid visibility settings.
+var Visibilities = []string{VisibilityPublic, VisibilityUnlisted, VisibilityInviteOnly}
+
+// InviteCode admits up to MaxUses registrations to an invite-only event.
+type InviteCode struct {
+	Code      string     `json:"code"`
+	EventID   string     `json:"event_id"`
+	Label     string     `json:"label,omitempty"`
+	MaxUses   int        `json:"max_uses"`
+	UsedCount int        `json:"used_count"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CreateInvitesRequest is the payload for generating invite codes in bulk.
+type CreateInvitesRequest struct {
+	Count   int    `json:"count"`
+	MaxUses int    `json:"max_uses,omitempty"` // 0 means single-use
+	Label   string `json:"label,omitempty"`
+}
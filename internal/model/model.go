@@ -1,46 +1,157 @@
 // Package model defines the core domain types for the event booking system.
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Event represents a bookable event created by an organizer.
 type Event struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Capacity    int       `json:"capacity"`
-	BookedCount int       `json:"booked_count"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	Description       string     `json:"description"`
+	Capacity          int        `json:"capacity"` // physical seats
+	BookedCount       int        `json:"booked_count"`
+	OversellPercent   int        `json:"oversell_percent,omitempty"` // extra seats sold as a share of Capacity
+	OversellSeats     int        `json:"oversell_seats,omitempty"`   // or as a fixed number
+	StartsAt          *time.Time `json:"starts_at,omitempty"`
+	EndsAt            *time.Time `json:"ends_at,omitempty"`
+	VenueID           *string    `json:"venue_id,omitempty"`
+	Tags              []string   `json:"tags"`
+	MaxGroupSize      int        `json:"max_group_size"` // attendees one group registration may book
+	SeriesID          *string    `json:"series_id,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	RequiresApproval  bool       `json:"requires_approval"`          // registrations become applications an organizer decides
+	BallotClosesAt    *time.Time `json:"ballot_closes_at,omitempty"` // set while a ballot awaits its draw
+	Visibility        string     `json:"visibility"`                 // one of Visibilities
+	ReservedSeating   bool       `json:"reserved_seating"`           // every registration picks a seat of the venue's seat map
+	TransfersDisabled bool       `json:"transfers_disabled"`         // holders cannot hand their tickets on
+	PriceCents        int        `json:"price_cents"`                // in the minor unit of Currency; 0 is free
+	Currency          string     `json:"currency"`                   // ISO 4217 code
+
+	// AllocationRemaining is the number of seats allocations still hold
+	// back from public sale.
+	AllocationRemaining int `json:"allocation_remaining"`
+
+	// PendingCount is the number of seats held by orders awaiting payment.
+	PendingCount int `json:"pending_count"`
+
+	// DistanceKM is the venue's distance from the search point; only set by
+	// searches with EventSearch.Near.
+	DistanceKM *float64 `json:"distance_km,omitempty"`
 }
 
-// Remaining returns the number of available seats.
+// SellableCapacity returns the number of seats that may be booked: the
+// physical capacity plus the oversell allowance, if any.  It matches the
+// sellable_capacity column.
+func (e *Event) SellableCapacity() int {
+	return e.Capacity + e.OversellSeats + e.Capacity*e.OversellPercent/100
+}
+
+// Remaining returns the number of seats available to the public: sellable
+// capacity not yet booked nor held by an allocation or a pending order.
 func (e *Event) Remaining() int {
-	return e.Capacity - e.BookedCount
+	return e.SellableCapacity() - e.BookedCount - e.AllocationRemaining - e.PendingCount
+}
+
+// IsPaid reports whether tickets for the event must be paid for.
+func (e *Event) IsPaid() bool {
+	return e.PriceCents > 0
 }
 
-// IsFull returns true when no seats remain.
+// IsFull returns true when no seats remain for the public.
 func (e *Event) IsFull() bool {
-	return e.BookedCount >= e.Capacity
+	return e.Remaining() <= 0
+}
+
+// MarshalJSON adds sellable_capacity and public_remaining, the seats anyone
+// may still book, next to the stored fields.
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event // without this method
+	return json.Marshal(struct {
+		event
+		SellableCapacity int `json:"sellable_capacity"`
+		PublicRemaining  int `json:"public_remaining"`
+	}{event(e), e.SellableCapacity(), e.Remaining()})
 }
 
 // Registration represents a user's registration for an event.
 type Registration struct {
-	ID        string    `json:"id"`
-	EventID   string    `json:"event_id"`
-	UserEmail string    `json:"user_email"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          string     `json:"id"`
+	EventID     string     `json:"event_id"`
+	UserEmail   string     `json:"user_email"`
+	Name        string     `json:"name,omitempty"`
+	GroupID     *string    `json:"group_id,omitempty"`
+	InviteCode  *string    `json:"invite_code,omitempty"`
+	SalePhase   *string    `json:"sale_phase,omitempty"`  // the phase that sold the seat
+	Allocation  *string    `json:"allocation,omitempty"`  // set on complimentary registrations
+	SeatID      *string    `json:"seat_id,omitempty"`     // set at events with reserved seating
+	TicketCode  string     `json:"ticket_code,omitempty"` // the holder's secret; replaced on transfer
+	Answers     Answers    `json:"answers,omitempty"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
 }
 
 // CreateEventRequest is the payload for creating a new event.
 type CreateEventRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Capacity    int    `json:"capacity"`
+	Name              string     `json:"name"`
+	Description       string     `json:"description"`
+	Capacity          int        `json:"capacity"`
+	StartsAt          *time.Time `json:"starts_at,omitempty"`
+	EndsAt            *time.Time `json:"ends_at,omitempty"`
+	VenueID           *string    `json:"venue_id,omitempty"`
+	Tags              []string   `json:"tags,omitempty"`
+	MaxGroupSize      int        `json:"max_group_size,omitempty"` // 0 means DefaultMaxGroupSize
+	RequiresApproval  bool       `json:"requires_approval,omitempty"`
+	Visibility        string     `json:"visibility,omitempty"` // "" means VisibilityPublic
+	OversellPercent   int        `json:"oversell_percent,omitempty"`
+	OversellSeats     int        `json:"oversell_seats,omitempty"` // at most one of the two
+	ReservedSeating   bool       `json:"reserved_seating,omitempty"`
+	TransfersDisabled bool       `json:"transfers_disabled,omitempty"`
+	PriceCents        int        `json:"price_cents,omitempty"`
+	Currency          string     `json:"currency,omitempty"` // "" means DefaultCurrency
+}
+
+// UpdateEventRequest is the payload for a partial event update. Nil fields
+// are left unchanged.
+type UpdateEventRequest struct {
+	Name              *string    `json:"name,omitempty"`
+	Description       *string    `json:"description,omitempty"`
+	Capacity          *int       `json:"capacity,omitempty"`
+	StartsAt          *time.Time `json:"starts_at,omitempty"`
+	EndsAt            *time.Time `json:"ends_at,omitempty"`
+	VenueID           *string    `json:"venue_id,omitempty"` // "" removes the venue
+	Tags              *[]string  `json:"tags,omitempty"`     // replaces the whole set; [] clears it
+	MaxGroupSize      *int       `json:"max_group_size,omitempty"`
+	RequiresApproval  *bool      `json:"requires_approval,omitempty"`
+	Visibility        *string    `json:"visibility,omitempty"`
+	OversellPercent   *int       `json:"oversell_percent,omitempty"` // a non-zero value clears OversellSeats
+	OversellSeats     *int       `json:"oversell_seats,omitempty"`   // a non-zero value clears OversellPercent
+	ReservedSeating   *bool      `json:"reserved_seating,omitempty"`
+	TransfersDisabled *bool      `json:"transfers_disabled,omitempty"`
+	PriceCents        *int       `json:"price_cents,omitempty"`
+	Currency          *string    `json:"currency,omitempty"`
 }
 
 // RegisterRequest is the payload for registering for an event.
 type RegisterRequest struct {
-	UserEmail string `json:"user_email"`
+	UserEmail  string  `json:"user_email"`
+	Name       string  `json:"name,omitempty"`
+	Answers    Answers `json:"answers,omitempty"` // keyed by question id
+	InviteCode string  `json:"invite_code,omitempty"`
+	AccessCode string  `json:"access_code,omitempty"` // unlocks a presale
+	SeatID     string  `json:"seat_id,omitempty"`     // required at events with reserved seating
+}
+
+// RegisterResult is the outcome of a registration request: a booked
+// Registration, or for events that do not book on request a pending
+// Application, BallotEntry or Order.  Exactly one is set.
+type RegisterResult struct {
+	Registration *Registration
+	Application  *Application
+	BallotEntry  *BallotEntry
+	Order        *Order
 }
 
 // ErrorResponse is a standard JSON error envelope.
@@ -55,3 +166,60 @@ type BookingResult struct {
 	Success   bool
 	Error     error
 }
+
+// Event list sort orders.
+const (
+	EventSortNewest    = "newest"    // created_at, newest first
+	EventSortSoonest   = "soonest"   // starts_at, soonest first; unscheduled events last
+	EventSortRemaining = "remaining" // most remaining seats first
+	EventSortRelevance = "relevance" // full-text rank of Query, best first
+	EventSortDistance  = "distance"  // venue distance from Near, closest first
+)
+
+// EventSorts lists the valid sort orders.
+var EventSorts = []string{EventSortNewest, EventSortSoonest, EventSortRemaining, EventSortRelevance, EventSortDistance}
+
+// Event availability filters.
+const (
+	AvailabilityOpen    = "open"     // at least one seat left
+	AvailabilitySoldOut = "sold_out" // no seats left
+)
+
+// EventSearch holds the search, filter and sort options of ListEvents. The
+// zero value lists every event, newest first.
+type EventSearch struct {
+	Query        string     // full-text query on name and description
+	Availability string     // "", AvailabilityOpen or AvailabilitySoldOut
+	StartsFrom   *time.Time // only events starting at or after this time
+	StartsBefore *time.Time // only events starting before this time
+	Tags         []string   // only events carrying every one of these tags
+	Near         *GeoPoint  // only events whose venue is within RadiusKM of this point
+	RadiusKM     float64
+	Sort         string // one of EventSorts
+}
+
+// IsZero reports whether no search, filter or sort option is set.
+func (s EventSearch) IsZero() bool {
+	return s.Query == "" && s.Availability == "" && s.StartsFrom == nil &&
+		s.StartsBefore == nil && len(s.Tags) == 0 && s.Near == nil && s.RadiusKM == 0 && s.Sort == ""
+}
+
+// Tag is a normalized event tag with the number of events carrying it.
+type Tag struct {
+	Name       string `json:"name"`
+	EventCount int    `json:"event_count"`
+}
+
+// PageRequest selects one page of a cursor-paginated list. An empty Cursor
+// starts at the beginning.
+type PageRequest struct {
+	Cursor string
+	Limit  int
+}
+
+// Page is one page of a cursor-paginated list. NextCursor is empty on the
+// last page.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Notification kinds stored in the outbox.
+const (
+	NotificationConfirmation = "registration.confirmed"
+	NotificationCancellation = "registration.cancelled"
+	NotificationReminder     = "event.reminder"
+	NotificationRejection    = "application.rejected"
+)
+
+// Outbox message delivery states.
+const (
+	OutboxPending = "pending"
+	OutboxSent    = "sent"
+	OutboxDead    = "dead"
+)
+
+// OutboxMessage is a notification recorded in the same transaction as the
+// state change that caused it. The dispatcher delivers it after COMMIT.
+type OutboxMessage struct {
+	ID            string          `json:"id"`
+	Kind          string          `json:"kind"`
+	Recipient     string          `json:"recipient"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// NotificationPayload is the data captured with an outbox message. It is a
+// snapshot taken inside the booking transaction, so the dispatcher can build
+// the message without another round-trip.
+type NotificationPayload struct {
+	EventID        string `json:"event_id"`
+	EventName      string `json:"event_name"`
+	RegistrationID string `json:"registration_id"`
+	UserEmail      string `json:"user_email"`
+	TicketCode     string `json:"ticket_code,omitempty"`  // confirmations only
+	RefundCents    int    `json:"refund_cents,omitempty"` // cancellations of paid tickets only
+	RefundPercent  int    `json:"refund_percent,omitempty"`
+	RefundCurrency string `json:"refund_currency,omitempty"`
+}
+
+// Template kinds. Each notification kind is rendered from one of these.
+const (
+	TemplateConfirmation = "confirmation"
+	TemplateReminder     = "reminder"
+	TemplateCancellation = "cancellation"
+	TemplateRejection    = "rejection"
+)
+
+// TemplateKinds lists every template kind in display order.
+var TemplateKinds = []string{TemplateConfirmation, TemplateReminder, TemplateCancellation, TemplateRejection}
+
+// MessageTemplate is the subject and body of one kind of notification.
+// Subject and TextBody use text/template; HTMLBody uses html/template and
+// may be empty for text-only mail.
+type MessageTemplate struct {
+	EventID   string    `json:"event_id,omitempty"`
+	Kind      string    `json:"kind"`
+	Subject   string    `json:"subject"`
+	TextBody  string    `json:"text_body"`
+	HTMLBody  string    `json:"html_body"`
+	IsDefault bool      `json:"is_default"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// SaveTemplateRequest is the payload for overriding a template on an event.
+type SaveTemplateRequest struct {
+	Subject  string `json:"subject"`
+	TextBody string `json:"text_body"`
+	HTMLBody string `json:"html_body"`
+}
+
+// TemplatePreview is a template rendered for a sample registration.
+type TemplatePreview struct {
+	Subject     string   `json:"subject"`
+	TextBody    string   `json:"text_body"`
+	HTMLBody    string   `json:"html_body,omitempty"`
+	Attachments []string `json:"attachments"`
+}
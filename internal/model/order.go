@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// DefaultCurrency is an event's currency unless the organizer sets another.
+const DefaultCurrency = "USD"
+
+// Order states.  An order goes pending → paid → fulfilled → cancelled, or
+// pending → failed or expired.  Only a pending order holds a seat.
+const (
+	OrderPending   = "pending"
+	OrderPaid      = "paid"      // paid, but no seat could be booked; refunded in full
+	OrderFulfilled = "fulfilled" // paid and booked as RegistrationID
+	OrderCancelled = "cancelled" // fulfilled, then RegistrationID was cancelled
+	OrderFailed    = "failed"
+	OrderExpired   = "expired"
+)
+
+// OrderStatuses lists the valid order states.
+var OrderStatuses = []string{OrderPending, OrderPaid, OrderFulfilled, OrderCancelled, OrderFailed, OrderExpired}
+
+// Order is the purchase of one ticket for a paid event.  While pending it
+// holds a seat until ExpiresAt; the buyer pays at CheckoutURL.
+type Order struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	UserEmail      string     `json:"user_email"`
+	Name           string     `json:"name,omitempty"`
+	Answers        Answers    `json:"answers,omitempty"`
+	InviteCode     *string    `json:"invite_code,omitempty"`
+	SalePhase      *string    `json:"sale_phase,omitempty"`
+	SeatID         *string    `json:"seat_id,omitempty"`
+	AmountCents    int        `json:"amount_cents"`
+	Currency       string     `json:"currency"`
+	Status         string     `json:"status"`
+	Provider       string     `json:"provider"`
+	PaymentID      *string    `json:"payment_id,omitempty"`
+	CheckoutURL    string     `json:"checkout_url,omitempty"`
+	RegistrationID *string    `json:"registration_id,omitempty"`
+	FailureReason  string     `json:"failure_reason,omitempty"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// SalePhase is a window in which an event's seats are on sale.  A phase
+// with a Code is a presale open only to attendees who give it; one with an
+// Allocation can sell at most that many seats, and holds them back from
+// every other phase until it ends.
+type SalePhase struct {
+	Name       string     `json:"name"`
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     *time.Time `json:"ends_at,omitempty"` // nil: open until the event
+	Code       string     `json:"code,omitempty"`
+	Allocation *int       `json:"allocation,omitempty"`
+	Sold       int        `json:"sold"`
+}
+
+// IsOpen reports whether the phase is on sale at t.
+func (p *SalePhase) IsOpen(t time.Time) bool {
+	return !t.Before(p.StartsAt) && (p.EndsAt == nil || t.Before(*p.EndsAt))
+}
+
+// Held returns how many seats the phase holds back from other phases at t:
+// its unsold allocation until it ends, and nothing afterwards.
+func (p *SalePhase) Held(t time.Time) int {
+	if p.Allocation == nil || (p.EndsAt != nil && !t.Before(*p.EndsAt)) {
+		return 0
+	}
+	return max(*p.Allocation-p.Sold, 0)
+}
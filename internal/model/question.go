@@ -0,0 +1,27 @@
+package model
+
+// Registration question types.
+const (
+	QuestionText         = "text"          // free text; the answer is a string
+	QuestionSingleChoice = "single_choice" // one of Options; the answer is a string
+	QuestionMultiChoice  = "multi_choice"  // any of Options; the answer is a list of strings
+	QuestionBoolean      = "boolean"       // yes or no; the answer is true or false
+)
+
+// QuestionTypes lists the valid question types.
+var QuestionTypes = []string{QuestionText, QuestionSingleChoice, QuestionMultiChoice, QuestionBoolean}
+
+// Question is one field of an event's registration form.  ID is the
+// organizer's stable key for the answer (e.g. "dietary"); Options are only
+// used by the choice types.
+type Question struct {
+	ID       string   `json:"id"`
+	Label    string   `json:"label"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
+}
+
+// Answers maps question ids to the answers given: a string, a list of
+// strings or a bool depending on the question type.
+type Answers map[string]any
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// RefundRule refunds Percent of the price to a cancellation made at least
+// DaysBefore days before the event starts.
+type RefundRule struct {
+	DaysBefore int `json:"days_before"`
+	Percent    int `json:"percent"`
+}
+
+// RefundPercent returns the share of the price, in percent, that rules
+// refund to a cancellation at now of an event starting at startsAt.  The
+// rule with the largest DaysBefore that still applies wins; an event
+// without a start time is always early enough for every rule.  Without
+// rules, or once the last rule's deadline has passed, nothing is refunded.
+func RefundPercent(rules []RefundRule, startsAt *time.Time, now time.Time) int {
+	rules = slices.Clone(rules)
+	slices.SortFunc(rules, func(a, b RefundRule) int { return b.DaysBefore - a.DaysBefore })
+	for _, r := range rules {
+		if startsAt == nil || !now.After(startsAt.AddDate(0, 0, -r.DaysBefore)) {
+			return r.Percent
+		}
+	}
+	return 0
+}
+
+// Refund reasons.
+const (
+	RefundReasonCancelled  = "cancelled"  // the attendee's registration was cancelled
+	RefundReasonUnbookable = "unbookable" // the order was paid but no seat could be booked
+)
+
+// Refund states.  A refund stays pending, retried with backoff, until the
+// payment provider confirms it; after too many attempts it fails and waits
+// for an operator to retry it.
+const (
+	RefundPending   = "pending"
+	RefundSucceeded = "succeeded"
+	RefundFailed    = "failed"
+)
+
+// RefundStatuses lists the valid refund states.
+var RefundStatuses = []string{RefundPending, RefundSucceeded, RefundFailed}
+
+// Refund is money owed back on an order.
+type Refund struct {
+	ID               string     `json:"id"`
+	EventID          string     `json:"event_id"`
+	OrderID          string     `json:"order_id"`
+	RegistrationID   *string    `json:"registration_id,omitempty"`
+	Reason           string     `json:"reason"`
+	Percent          int        `json:"percent"`
+	AmountCents      int        `json:"amount_cents"`
+	Currency         string     `json:"currency"`
+	Status           string     `json:"status"`
+	ProviderRefundID *string    `json:"provider_refund_id,omitempty"`
+	Attempts         int        `json:"attempts"`
+	LastError        string     `json:"last_error,omitempty"`
+	NextAttemptAt    time.Time  `json:"next_attempt_at"`
+	RefundedAt       *time.Time `json:"refunded_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefundPercent(t *testing.T) {
+	startsAt := time.Date(2026, 6, 30, 18, 0, 0, 0, time.UTC)
+	policy := []RefundRule{
+		{DaysBefore: 1, Percent: 25},
+		{DaysBefore: 14, Percent: 100},
+		{DaysBefore: 7, Percent: 50},
+	}
+
+	tests := []struct {
+		name     string
+		rules    []RefundRule
+		startsAt *time.Time
+		now      time.Time
+		want     int
+	}{
+		{"no rules", nil, &startsAt, startsAt.AddDate(0, -1, 0), 0},
+		{"well before every deadline", policy, &startsAt, startsAt.AddDate(0, -1, 0), 100},
+		{"exactly at the first deadline", policy, &startsAt, startsAt.AddDate(0, 0, -14), 100},
+		{"just past the first deadline", policy, &startsAt, startsAt.AddDate(0, 0, -14).Add(time.Second), 50},
+		{"between the middle rules", policy, &startsAt, startsAt.AddDate(0, 0, -3), 25},
+		{"exactly at the last deadline", policy, &startsAt, startsAt.AddDate(0, 0, -1), 25},
+		{"past the last deadline", policy, &startsAt, startsAt.Add(-time.Hour), 0},
+		{"after the start", policy, &startsAt, startsAt.Add(time.Hour), 0},
+		{"same-day rule until the start", []RefundRule{{DaysBefore: 0, Percent: 10}}, &startsAt, startsAt, 10},
+		{"same-day rule after the start", []RefundRule{{DaysBefore: 0, Percent: 10}}, &startsAt, startsAt.Add(time.Second), 0},
+		{"no start time", policy, nil, startsAt.AddDate(1, 0, 0), 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RefundPercent(tt.rules, tt.startsAt, tt.now); got != tt.want {
+				t.Errorf("RefundPercent = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if policy[0].DaysBefore != 1 || policy[1].DaysBefore != 14 || policy[2].DaysBefore != 7 {
+		t.Errorf("RefundPercent reordered the caller's rules: %v", policy)
+	}
+}
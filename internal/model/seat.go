@@ -0,0 +1,46 @@
+package model
+
+// Seat statuses in an event's seat availability.
+const (
+	SeatAvailable = "available"
+	SeatTaken     = "taken"
+)
+
+// SeatMap is the layout of a venue's numbered seats: sections of rows of
+// seats, in the order they are drawn.
+type SeatMap struct {
+	Sections []SeatSection `json:"sections"`
+}
+
+// SeatSection is a named block of rows, such as "Stalls" or "Balcony".
+type SeatSection struct {
+	Name string    `json:"name"`
+	Rows []SeatRow `json:"rows"`
+}
+
+// SeatRow is one row of a section.
+type SeatRow struct {
+	Label string `json:"label"`
+	Seats []Seat `json:"seats"`
+}
+
+// Seat is one seat of a seat map.  Its ID is assigned when the map is saved
+// and kept as long as the seat stays in the map under the same section, row
+// and label.
+type Seat struct {
+	ID         string `json:"id,omitempty"`
+	Label      string `json:"label"`
+	Accessible bool   `json:"accessible,omitempty"`
+	Status     string `json:"status,omitempty"` // set in an event's availability: SeatAvailable or SeatTaken
+}
+
+// Count returns the number of seats in the map.
+func (m *SeatMap) Count() int {
+	n := 0
+	for _, s := range m.Sections {
+		for _, r := range s.Rows {
+			n += len(r.Seats)
+		}
+	}
+	return n
+}
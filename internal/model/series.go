@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// Scopes for edits and deletes made through a series occurrence.
+const (
+	SeriesScopeThis   = "this"   // only the chosen occurrence
+	SeriesScopeFuture = "future" // the chosen occurrence and every later one
+)
+
+// EventSeries is a recurring event: a template and an RRULE-style recurrence
+// from which occurrences are generated as ordinary events, each with its own
+// capacity and registrations.
+type EventSeries struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Capacity    int        `json:"capacity"`
+	VenueID     *string    `json:"venue_id,omitempty"`
+	Tags        []string   `json:"tags"`
+	RRule       string     `json:"rrule"`
+	Timezone    string     `json:"timezone"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	// Occurrences are the series' events in start order; only set by GetSeries.
+	Occurrences []Event `json:"occurrences,omitempty"`
+}
+
+// CreateSeriesRequest is the payload for creating a series.  StartsAt is the
+// first possible occurrence; its time of day in Timezone is used for every
+// occurrence, and EndsAt, if set, gives each occurrence the same duration.
+type CreateSeriesRequest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Capacity    int        `json:"capacity"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	VenueID     *string    `json:"venue_id,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	RRule       string     `json:"rrule"`              // e.g. "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10"
+	Timezone    string     `json:"timezone,omitempty"` // IANA name; defaults to UTC
+}
+
+// UpdateSeriesRequest is the payload for editing occurrences of a series.
+// The event fields apply to every occurrence in scope; StartsAt moves the
+// chosen occurrence and shifts later ones by the same amount, and EndsAt
+// sets the duration.  RRule and Timezone are only accepted for the future
+// scope and regenerate the occurrences from the chosen one onwards.
+type UpdateSeriesRequest struct {
+	UpdateEventRequest
+	RRule    *string `json:"rrule,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// Session is a time slot inside an event with its own capacity.  Attendees
+// registered for the event pick the sessions they want to attend.
+type Session struct {
+	ID          string    `json:"id"`
+	EventID     string    `json:"event_id"`
+	Title       string    `json:"title"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Capacity    int       `json:"capacity"`
+	BookedCount int       `json:"booked_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// IsFull returns true when no seats remain in the session.
+func (s *Session) IsFull() bool {
+	return s.BookedCount >= s.Capacity
+}
+
+// CreateSessionRequest is the payload for adding a session to an event.
+type CreateSessionRequest struct {
+	Title    string     `json:"title"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+	Capacity int        `json:"capacity"`
+}
+
+// PickSessionRequest is the payload for an attendee picking a session.
+type PickSessionRequest struct {
+	SessionID string `json:"session_id"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// TransferRequest is the payload for handing a ticket to another attendee.
+// TicketCode is the current holder's code and authorizes the transfer; the
+// new attendee answers the event's questions like any registrant.
+type TransferRequest struct {
+	TicketCode string  `json:"ticket_code"`
+	UserEmail  string  `json:"user_email"` // the new attendee
+	Name       string  `json:"name,omitempty"`
+	Answers    Answers `json:"answers,omitempty"`
+}
+
+// TicketTransfer records one change of a registration's holder.
+type TicketTransfer struct {
+	ID             string    `json:"id"`
+	EventID        string    `json:"event_id"`
+	RegistrationID string    `json:"registration_id"`
+	FromEmail      string    `json:"from_email"`
+	ToEmail        string    `json:"to_email"`
+	TransferredAt  time.Time `json:"transferred_at"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Venue is a physical location events can be held at.
+type Venue struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Address     string    `json:"address"`
+	Latitude    float64   `json:"latitude"`
+	Longitude   float64   `json:"longitude"`
+	MaxCapacity int       `json:"max_capacity"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateVenueRequest is the payload for creating a venue.
+type CreateVenueRequest struct {
+	Name        string   `json:"name"`
+	Address     string   `json:"address"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	MaxCapacity int      `json:"max_capacity"`
+}
+
+// GeoPoint is a WGS 84 coordinate in degrees.
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook event types organizers can subscribe to.
+const (
+	WebhookRegistrationCreated     = "registration.created"
+	WebhookRegistrationCancelled   = "registration.cancelled"
+	WebhookRegistrationTransferred = "registration.transferred"
+	WebhookEventUpdated            = "event.updated"
+	WebhookEventFull               = "event.full"
+)
+
+// WebhookEventTypes lists every subscribable webhook event type.
+var WebhookEventTypes = []string{
+	WebhookRegistrationCreated,
+	WebhookRegistrationCancelled,
+	WebhookRegistrationTransferred,
+	WebhookEventUpdated,
+	WebhookEventFull,
+}
+
+// Webhook delivery states.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// WebhookEndpoint is an organizer-registered URL that receives signed POSTs.
+// A nil EventID means the endpoint is account-wide (all events).
+type WebhookEndpoint struct {
+	ID         string    `json:"id"`
+	EventID    *string   `json:"event_id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is one attempt-tracked POST of a payload to an endpoint.
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	EndpointID     string          `json:"endpoint_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode *int            `json:"last_status_code"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// WebhookPayload is the JSON body POSTed to endpoints. ID identifies the
+// occurrence and is shared by every endpoint and every retry, so receivers
+// can de-duplicate.
+type WebhookPayload struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// WebhookRegistration is a registration as registration.* webhooks carry
+// it.  It has no ticket code: anyone can register an endpoint, and the code
+// authorizes a transfer.
+type WebhookRegistration struct {
+	ID          string     `json:"id"`
+	EventID     string     `json:"event_id"`
+	UserEmail   string     `json:"user_email"`
+	Name        string     `json:"name,omitempty"`
+	GroupID     *string    `json:"group_id,omitempty"`
+	InviteCode  *string    `json:"invite_code,omitempty"`
+	SalePhase   *string    `json:"sale_phase,omitempty"`
+	Allocation  *string    `json:"allocation,omitempty"`
+	SeatID      *string    `json:"seat_id,omitempty"`
+	Answers     Answers    `json:"answers,omitempty"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// NewWebhookRegistration returns reg as webhooks carry it.
+func NewWebhookRegistration(reg *Registration) WebhookRegistration {
+	return WebhookRegistration{
+		ID:          reg.ID,
+		EventID:     reg.EventID,
+		UserEmail:   reg.UserEmail,
+		Name:        reg.Name,
+		GroupID:     reg.GroupID,
+		InviteCode:  reg.InviteCode,
+		SalePhase:   reg.SalePhase,
+		Allocation:  reg.Allocation,
+		SeatID:      reg.SeatID,
+		Answers:     reg.Answers,
+		CheckedInAt: reg.CheckedInAt,
+		CreatedAt:   reg.CreatedAt,
+	}
+}
+
+// CreateWebhookRequest is the payload for registering a webhook endpoint.
+type CreateWebhookRequest struct {
+	EventID    *string  `json:"event_id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
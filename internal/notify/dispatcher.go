@@ -0,0 +1,179 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// Dispatcher tuning. Backoff doubles from retryBase up to retryCap; after
+// maxAttempts failed deliveries a message is parked in the dead state.
+// Messages are claimed one at a time, each just before it is sent, and
+// claimLease must outlast smtpTimeout so that no other dispatcher can claim
+// a message while it is still being sent.
+const (
+	pollInterval = 2 * time.Second
+	batchSize    = 20
+	claimLease   = 2 * smtpTimeout
+	retryBase    = 30 * time.Second
+	retryCap     = time.Hour
+	maxAttempts  = 8
+)
+
+// Dispatcher polls the outbox and delivers due messages through a Notifier.
+type Dispatcher struct {
+	outbox    *repository.OutboxRepository
+	events    *repository.EventRepository
+	templates *repository.TemplateRepository
+	notifier  Notifier
+}
+
+// NewDispatcher constructs a Dispatcher.
+func NewDispatcher(
+	outbox *repository.OutboxRepository,
+	events *repository.EventRepository,
+	templates *repository.TemplateRepository,
+	notifier Notifier,
+) *Dispatcher {
+	return &Dispatcher{outbox: outbox, events: events, templates: templates, notifier: notifier}
+}
+
+// Run polls until ctx is cancelled. A batch already in flight is finished
+// before Run returns, so shutdown does not abandon half-sent messages.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain everything that is due before sleeping again.
+		for {
+			n, err := d.dispatchBatch(context.WithoutCancel(ctx))
+			if err != nil {
+				log.Printf("outbox: %v", err)
+				break
+			}
+			if n < batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchBatch claims and delivers up to batchSize messages, returning how
+// many were claimed.  Each message is claimed on its own right before it is
+// sent, so its lease covers only its own delivery, however slow the ones
+// before it were.
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	claimed := 0
+	for claimed < batchSize {
+		msgs, err := d.outbox.Claim(ctx, 1, claimLease)
+		if err != nil {
+			return claimed, err
+		}
+		if len(msgs) == 0 {
+			break
+		}
+		claimed++
+		m := msgs[0]
+
+		sendErr := d.deliver(ctx, m)
+		if sendErr == nil {
+			if err := d.outbox.MarkSent(ctx, m.ID); err != nil {
+				log.Printf("outbox: %v", err)
+			}
+			continue
+		}
+
+		var retryAt *time.Time
+		if m.Attempts < maxAttempts {
+			t := time.Now().UTC().Add(backoff(m.Attempts))
+			retryAt = &t
+		}
+		log.Printf("outbox: deliver %s to %s (attempt %d): %v", m.Kind, m.Recipient, m.Attempts, sendErr)
+		if err := d.outbox.MarkFailed(ctx, m.ID, sendErr, retryAt); err != nil {
+			log.Printf("outbox: %v", err)
+		}
+	}
+	return claimed, nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, m model.OutboxMessage) error {
+	msg, err := d.compose(ctx, m)
+	if err != nil {
+		return err
+	}
+	return d.notifier.Notify(ctx, msg)
+}
+
+// compose renders an outbox row with the event's template override for its
+// kind, falling back to the default template.
+func (d *Dispatcher) compose(ctx context.Context, m model.OutboxMessage) (Message, error) {
+	kind, ok := templateKindFor[m.Kind]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown notification kind %q", m.Kind)
+	}
+
+	var p model.NotificationPayload
+	if err := json.Unmarshal(m.Payload, &p); err != nil {
+		return Message{}, fmt.Errorf("decode payload: %w", err)
+	}
+
+	event, err := d.events.GetByID(ctx, p.EventID)
+	if err != nil {
+		return Message{}, fmt.Errorf("load event: %w", err)
+	}
+
+	tpl, err := EffectiveTemplate(ctx, d.templates, p.EventID, kind)
+	if err != nil {
+		return Message{}, err
+	}
+
+	data := TemplateData{
+		Event: *event,
+		Registration: model.Registration{
+			ID:         p.RegistrationID,
+			EventID:    p.EventID,
+			UserEmail:  p.UserEmail,
+			TicketCode: p.TicketCode,
+		},
+	}
+	if p.RefundCents > 0 {
+		data.Refund = &model.Refund{
+			EventID:        p.EventID,
+			RegistrationID: &p.RegistrationID,
+			Reason:         model.RefundReasonCancelled,
+			Percent:        p.RefundPercent,
+			AmountCents:    p.RefundCents,
+			Currency:       p.RefundCurrency,
+		}
+	}
+	msg, err := Compose(tpl, data)
+	if err != nil {
+		return Message{}, err
+	}
+	msg.To = m.Recipient
+	return msg, nil
+}
+
+// backoff returns the delay before retry number attempt (1-based).
+func backoff(attempt int) time.Duration {
+	d := retryBase
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= retryCap {
+			return retryCap
+		}
+	}
+	return d
+}
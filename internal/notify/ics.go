@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// BuildICS renders a single-event iCalendar (RFC 5545) file for event.
+// The registration ID makes the UID stable, so a resent confirmation updates
+// the attendee's calendar entry instead of duplicating it.
+// event.StartsAt must be set; events without an end default to one hour.
+func BuildICS(event model.Event, registrationID string) []byte {
+	start := event.StartsAt.UTC()
+	end := start.Add(time.Hour)
+	if event.EndsAt != nil {
+		end = event.EndsAt.UTC()
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//EventBooking//Ticketing//EN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:" + registrationID + "@eventbooking",
+		"DTSTAMP:" + time.Now().UTC().Format(icsTimeFormat),
+		"DTSTART:" + start.Format(icsTimeFormat),
+		"DTEND:" + end.Format(icsTimeFormat),
+		"SUMMARY:" + icsEscape(event.Name),
+	}
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+icsEscape(event.Description))
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(icsFold(l))
+		b.WriteString("\r\n")
+	}
+	return []byte(b.String())
+}
+
+// icsEscape escapes a TEXT value.
+func icsEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// icsFold splits content lines longer than 75 octets, continuing each with a
+// leading space, without breaking a UTF-8 sequence.
+func icsFold(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		n := len(string(r))
+		if width+n > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
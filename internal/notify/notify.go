@@ -0,0 +1,312 @@
+// Package notify delivers attendee notifications (confirmation emails and the
+// like) through a pluggable Notifier, fed by the transactional outbox.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a fully composed notification ready to hand to a transport.
+// HTMLBody is optional; Body is always the plain-text alternative.
+type Message struct {
+	To          string
+	Subject     string
+	Body        string
+	HTMLBody    string
+	Attachments []Attachment
+}
+
+// Attachment is a file sent along with a message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Notifier sends a single message. Implementations must be safe for
+// concurrent use.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// ─── Writer notifier (stdout / file) ──────────────────────────────────────────
+
+// WriterNotifier writes each message as a plain-text block to an io.Writer.
+// It is meant for local development: point it at stdout or a file and read
+// the "sent" emails there.
+type WriterNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterNotifier constructs a WriterNotifier.
+func NewWriterNotifier(w io.Writer) *WriterNotifier {
+	return &WriterNotifier{w: w}
+}
+
+// Notify writes msg to the underlying writer.
+func (n *WriterNotifier) Notify(_ context.Context, msg Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var b strings.Builder
+	fmt.Fprintf(&b, "──── notification %s ────\nTo: %s\nSubject: %s\n",
+		time.Now().UTC().Format(time.RFC3339), msg.To, msg.Subject)
+	for _, a := range msg.Attachments {
+		fmt.Fprintf(&b, "Attachment: %s (%s, %d bytes)\n", a.Filename, a.ContentType, len(a.Data))
+	}
+	fmt.Fprintf(&b, "\n%s\n\n", msg.Body)
+	_, err := io.WriteString(n.w, b.String())
+	return err
+}
+
+// ─── SMTP notifier ────────────────────────────────────────────────────────────
+
+// SMTPConfig holds SMTP connection settings.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// smtpTimeout bounds one SMTP delivery, from dialling the relay to QUIT.
+const smtpTimeout = 30 * time.Second
+
+// SMTPNotifier sends messages through an SMTP relay using net/smtp.
+type SMTPNotifier struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPNotifier constructs an SMTPNotifier.
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+// Notify sends msg, giving up after smtpTimeout.  net/smtp has no context
+// support, so ctx is only checked before dialling; the timeout is enforced
+// with a deadline on the connection instead.
+func (n *SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	}
+
+	body, err := n.buildMIME(msg)
+	if err != nil {
+		return fmt.Errorf("build mime message: %w", err)
+	}
+
+	if err := n.send(auth, msg.To, body); err != nil {
+		return fmt.Errorf("smtp send: %w", err)
+	}
+	return nil
+}
+
+// send does what smtp.SendMail does, on a connection with a deadline
+// smtpTimeout away: STARTTLS when the relay offers it, AUTH when
+// credentials are set, and one message to one recipient.
+func (n *SMTPNotifier) send(auth smtp.Auth, to string, body []byte) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(n.cfg.Host, n.cfg.Port), smtpTimeout)
+	if err != nil {
+		return err
+	}
+	if err = conn.SetDeadline(time.Now().Add(smtpTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+	c, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: n.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("server doesn't support AUTH")
+		}
+		if err = c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err = c.Mail(n.cfg.From); err != nil {
+		return err
+	}
+	if err = c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(body); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// buildMIME encodes msg as multipart/mixed: a multipart/alternative part with
+// the text (and optional HTML) body, followed by any attachments.
+func (n *SMTPNotifier) buildMIME(msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	mixed := multipart.NewWriter(&buf)
+	altBoundary := multipart.NewWriter(io.Discard).Boundary()
+
+	fmt.Fprintf(&buf, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mixed.Boundary())
+
+	body, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%q", altBoundary)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	alt := multipart.NewWriter(body)
+	if err := alt.SetBoundary(altBoundary); err != nil {
+		return nil, err
+	}
+	parts := []struct{ contentType, content string }{{"text/plain; charset=UTF-8", msg.Body}}
+	if msg.HTMLBody != "" {
+		parts = append(parts, struct{ contentType, content string }{"text/html; charset=UTF-8", msg.HTMLBody})
+	}
+	for _, p := range parts {
+		w, err := alt.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(p.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := alt.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, a := range msg.Attachments {
+		w, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		enc := base64.NewEncoder(base64.StdEncoding, &lineWrapper{w: w})
+		if _, err := enc.Write(a.Data); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// lineWrapper breaks base64 output into 76-character lines (RFC 2045).
+type lineWrapper struct {
+	w   io.Writer
+	col int
+}
+
+func (l *lineWrapper) Write(p []byte) (int, error) {
+	const width = 76
+	written := 0
+	for len(p) > 0 {
+		n := min(width-l.col, len(p))
+		if _, err := l.w.Write(p[:n]); err != nil {
+			return written, err
+		}
+		written += n
+		l.col += n
+		p = p[n:]
+		if l.col == width {
+			if _, err := l.w.Write([]byte("\r\n")); err != nil {
+				return written, err
+			}
+			l.col = 0
+		}
+	}
+	return written, nil
+}
+
+// ─── Configuration ────────────────────────────────────────────────────────────
+
+// NewFromEnv builds a Notifier from environment variables:
+//
+//	NOTIFIER=stdout (default) | file | smtp
+//	NOTIFIER_FILE=notifications.log              (file)
+//	SMTP_HOST, SMTP_PORT, SMTP_USERNAME,
+//	SMTP_PASSWORD, SMTP_FROM                      (smtp)
+func NewFromEnv() (Notifier, error) {
+	switch kind := getEnv("NOTIFIER", "stdout"); kind {
+	case "stdout":
+		return NewWriterNotifier(os.Stdout), nil
+	case "file":
+		path := getEnv("NOTIFIER_FILE", "notifications.log")
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open notifier file: %w", err)
+		}
+		return NewWriterNotifier(f), nil
+	case "smtp":
+		cfg := SMTPConfig{
+			Host:     getEnv("SMTP_HOST", "localhost"),
+			Port:     getEnv("SMTP_PORT", "25"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnv("SMTP_FROM", "no-reply@eventbooking.local"),
+		}
+		return NewSMTPNotifier(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown NOTIFIER %q (want stdout, file or smtp)", kind)
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
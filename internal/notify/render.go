@@ -0,0 +1,273 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// TemplateData is the value templates are executed against, e.g.
+// {{.Event.Name}} or {{.Registration.UserEmail}}.  Refund is set only on
+// cancellations of paid tickets that are owed money back, so templates
+// must guard it with {{with .Refund}}.
+type TemplateData struct {
+	Event        model.Event
+	Registration model.Registration
+	Refund       *model.Refund
+}
+
+// SampleData returns template data for a made-up registration to event,
+// with a refund if the event is paid.  It is used for previews and to
+// test-render templates before saving them.
+func SampleData(event model.Event) TemplateData {
+	data := TemplateData{
+		Event: event,
+		Registration: model.Registration{
+			ID:         "00000000-0000-0000-0000-000000000000",
+			EventID:    event.ID,
+			UserEmail:  "attendee@example.com",
+			TicketCode: "0123456789abcdef0123456789abcdef",
+			CreatedAt:  time.Now().UTC(),
+		},
+	}
+	if event.IsPaid() {
+		data.Refund = sampleRefund(event)
+	}
+	return data
+}
+
+// sampleRefund returns a made-up full refund of a ticket to event.
+func sampleRefund(event model.Event) *model.Refund {
+	refund := &model.Refund{
+		EventID:     event.ID,
+		Reason:      model.RefundReasonCancelled,
+		Percent:     100,
+		AmountCents: event.PriceCents,
+		Currency:    event.Currency,
+	}
+	if refund.AmountCents <= 0 {
+		refund.AmountCents = 2500
+	}
+	if refund.Currency == "" {
+		refund.Currency = model.DefaultCurrency
+	}
+	return refund
+}
+
+// templateKindFor maps an outbox notification kind to the template that
+// renders it.
+var templateKindFor = map[string]string{
+	model.NotificationConfirmation: model.TemplateConfirmation,
+	model.NotificationCancellation: model.TemplateCancellation,
+	model.NotificationReminder:     model.TemplateReminder,
+	model.NotificationRejection:    model.TemplateRejection,
+}
+
+// funcs are available to every template.
+var funcs = map[string]any{
+	"datetime": func(v any) string { return formatTime(v, "Mon, 02 Jan 2006 15:04 MST") },
+	"date":     func(v any) string { return formatTime(v, "Mon, 02 Jan 2006") },
+	"money":    formatMoney,
+}
+
+// formatMoney renders an amount in minor units, e.g. "12.50 USD".
+func formatMoney(cents int, currency string) string {
+	return fmt.Sprintf("%d.%02d %s", cents/100, cents%100, currency)
+}
+
+func formatTime(v any, layout string) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case *time.Time:
+		if t != nil {
+			return t.Format(layout)
+		}
+	}
+	return "TBA"
+}
+
+// Compose renders tpl for data and adds the attachments that belong to its
+// kind (a calendar invite on confirmations of scheduled events).
+func Compose(tpl model.MessageTemplate, data TemplateData) (Message, error) {
+	msg, err := render(tpl, data)
+	if err != nil {
+		return Message{}, err
+	}
+	if tpl.Kind == model.TemplateConfirmation && data.Event.StartsAt != nil {
+		msg.Attachments = append(msg.Attachments, Attachment{
+			Filename:    "invite.ics",
+			ContentType: "text/calendar; charset=UTF-8; method=PUBLISH",
+			Data:        BuildICS(data.Event, data.Registration.ID),
+		})
+	}
+	return msg, nil
+}
+
+// Validate parses tpl and test-renders it against sample data for event, so
+// syntax errors and references to unknown fields are reported when the
+// template is saved rather than when a message is sent.  It renders both
+// with and without a refund, since only some messages carry one.
+func Validate(tpl model.MessageTemplate, event model.Event) error {
+	if strings.TrimSpace(tpl.Subject) == "" {
+		return fmt.Errorf("subject is required")
+	}
+	if strings.TrimSpace(tpl.TextBody) == "" {
+		return fmt.Errorf("text_body is required")
+	}
+	data := SampleData(event)
+	data.Refund = nil
+	if _, err := render(tpl, data); err != nil {
+		return err
+	}
+	data.Refund = sampleRefund(event)
+	if _, err := render(tpl, data); err != nil {
+		return fmt.Errorf("with a refund: %w", err)
+	}
+	return nil
+}
+
+func render(tpl model.MessageTemplate, data TemplateData) (Message, error) {
+	subject, err := execText("subject", tpl.Subject, data)
+	if err != nil {
+		return Message{}, err
+	}
+	text, err := execText("text_body", tpl.TextBody, data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	var html string
+	if tpl.HTMLBody != "" {
+		t, err := htmltemplate.New("html_body").Funcs(funcs).Parse(tpl.HTMLBody)
+		if err != nil {
+			return Message{}, fmt.Errorf("html_body: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return Message{}, fmt.Errorf("html_body: %w", err)
+		}
+		html = buf.String()
+	}
+
+	return Message{
+		To: data.Registration.UserEmail,
+		// A subject is a single header line; never let a template inject more.
+		Subject:  strings.Join(strings.Fields(subject), " "),
+		Body:     text,
+		HTMLBody: html,
+	}, nil
+}
+
+func execText(name, src string, data TemplateData) (string, error) {
+	t, err := texttemplate.New(name).Funcs(funcs).Parse(src)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// ─── Default template set ─────────────────────────────────────────────────────
+
+// DefaultTemplate returns the built-in template for kind. Events without an
+// override use these.
+func DefaultTemplate(kind string) (model.MessageTemplate, bool) {
+	t, ok := defaultTemplates[kind]
+	if ok {
+		t.Kind = kind
+		t.IsDefault = true
+	}
+	return t, ok
+}
+
+// EffectiveTemplate returns the event's override for kind, or the default
+// template when the event has none.
+func EffectiveTemplate(ctx context.Context, templates *repository.TemplateRepository, eventID, kind string) (model.MessageTemplate, error) {
+	tpl, err := templates.Get(ctx, eventID, kind)
+	if errors.Is(err, repository.ErrNotFound) {
+		def, ok := DefaultTemplate(kind)
+		if !ok {
+			return model.MessageTemplate{}, fmt.Errorf("unknown template kind %q", kind)
+		}
+		return def, nil
+	}
+	if err != nil {
+		return model.MessageTemplate{}, fmt.Errorf("load template: %w", err)
+	}
+	return *tpl, nil
+}
+
+var defaultTemplates = map[string]model.MessageTemplate{
+	model.TemplateConfirmation: {
+		Subject: `You're registered for {{.Event.Name}}`,
+		TextBody: `Hi,
+
+Your registration for "{{.Event.Name}}" is confirmed.
+{{if .Event.StartsAt}}
+When: {{datetime .Event.StartsAt}}{{if .Event.EndsAt}} – {{datetime .Event.EndsAt}}{{end}}
+{{end}}
+Confirmation ID: {{.Registration.ID}}{{with .Registration.TicketCode}}
+Ticket code: {{.}} (keep it private; it lets you transfer your ticket){{end}}
+
+See you there!`,
+		HTMLBody: `<p>Hi,</p>
+<p>Your registration for <strong>{{.Event.Name}}</strong> is confirmed.</p>
+{{if .Event.StartsAt}}<p>When: {{datetime .Event.StartsAt}}{{if .Event.EndsAt}} – {{datetime .Event.EndsAt}}{{end}}</p>{{end}}
+<p>Confirmation ID: <code>{{.Registration.ID}}</code></p>
+{{with .Registration.TicketCode}}<p>Ticket code: <code>{{.}}</code> (keep it private; it lets you transfer your ticket)</p>{{end}}
+<p>See you there!</p>`,
+	},
+	model.TemplateReminder: {
+		Subject: `Reminder: {{.Event.Name}} starts {{datetime .Event.StartsAt}}`,
+		TextBody: `Hi,
+
+This is a reminder that "{{.Event.Name}}" starts {{datetime .Event.StartsAt}}.
+
+Confirmation ID: {{.Registration.ID}}
+
+See you soon!`,
+		HTMLBody: `<p>Hi,</p>
+<p>This is a reminder that <strong>{{.Event.Name}}</strong> starts {{datetime .Event.StartsAt}}.</p>
+<p>Confirmation ID: <code>{{.Registration.ID}}</code></p>
+<p>See you soon!</p>`,
+	},
+	model.TemplateCancellation: {
+		Subject: `Your registration for {{.Event.Name}} was cancelled`,
+		TextBody: `Hi,
+
+Your registration for "{{.Event.Name}}" has been cancelled.
+Your seat has been released.
+{{with .Refund}}
+You will be refunded {{money .AmountCents .Currency}} ({{.Percent}}% of the price).
+{{end}}
+Confirmation ID: {{.Registration.ID}}`,
+		HTMLBody: `<p>Hi,</p>
+<p>Your registration for <strong>{{.Event.Name}}</strong> has been cancelled. Your seat has been released.</p>
+{{with .Refund}}<p>You will be refunded {{money .AmountCents .Currency}} ({{.Percent}}% of the price).</p>{{end}}
+<p>Confirmation ID: <code>{{.Registration.ID}}</code></p>`,
+	},
+	model.TemplateRejection: {
+		Subject: `Your application for {{.Event.Name}}`,
+		TextBody: `Hi,
+
+Thank you for applying to "{{.Event.Name}}". Unfortunately we are unable
+to offer you a place this time.
+
+Application ID: {{.Registration.ID}}`,
+		HTMLBody: `<p>Hi,</p>
+<p>Thank you for applying to <strong>{{.Event.Name}}</strong>. Unfortunately we are unable to offer you a place this time.</p>
+<p>Application ID: <code>{{.Registration.ID}}</code></p>`,
+	},
+}
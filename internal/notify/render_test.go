@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+)
+
+func TestValidate(t *testing.T) {
+	free := model.Event{ID: "event-1", Name: "Meetup", Currency: model.DefaultCurrency}
+	paid := free
+	paid.PriceCents = 1250
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string // "" means the template is valid
+	}{
+		{"no refund reference", "Hi {{.Registration.UserEmail}}, see you at {{.Event.Name}}.", ""},
+		{"refund guarded", "{{with .Refund}}Refund: {{money .AmountCents .Currency}} ({{.Percent}}%){{end}}", ""},
+		{"refund unguarded", "Refund: {{money .Refund.AmountCents .Refund.Currency}}", "text_body"},
+		{"unknown refund field", "{{with .Refund}}{{.Amount}}{{end}}", "with a refund"},
+		{"unknown field", "{{.Event.Nmae}}", "text_body"},
+		{"syntax error", "{{if .Refund}}unterminated", "text_body"},
+	}
+	for _, tt := range tests {
+		for _, event := range []model.Event{free, paid} {
+			tpl := model.MessageTemplate{Kind: model.TemplateCancellation, Subject: "Cancelled", TextBody: tt.body}
+			err := Validate(tpl, event)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("%s (price %d): unexpected error %v", tt.name, event.PriceCents, err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("%s (price %d): err = %v, want one mentioning %q", tt.name, event.PriceCents, err, tt.wantErr)
+			}
+		}
+	}
+
+	for _, kind := range model.TemplateKinds {
+		tpl, _ := DefaultTemplate(kind)
+		for _, event := range []model.Event{free, paid} {
+			if err := Validate(tpl, event); err != nil {
+				t.Errorf("default %s template (price %d): %v", kind, event.PriceCents, err)
+			}
+		}
+	}
+}
+
+func TestSampleDataRefund(t *testing.T) {
+	free := model.Event{ID: "event-1", Currency: "EUR"}
+	if data := SampleData(free); data.Refund != nil {
+		t.Errorf("free event: sample refund %+v, want none", data.Refund)
+	}
+
+	paid := free
+	paid.PriceCents = 1250
+	data := SampleData(paid)
+	if data.Refund == nil {
+		t.Fatal("paid event: no sample refund")
+	}
+	if data.Refund.AmountCents != 1250 || data.Refund.Currency != "EUR" || data.Refund.Percent != 100 {
+		t.Errorf("paid event: sample refund %+v, want a full refund of 1250 EUR", data.Refund)
+	}
+}
@@ -0,0 +1,136 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Callback headers.  The signature is "v1=" followed by the hex
+// HMAC-SHA256 of "<timestamp>.<body>", as for organizer webhooks.
+const (
+	HeaderTimestamp = "Payment-Timestamp"
+	HeaderSignature = "Payment-Signature"
+)
+
+// ErrSimulationUnavailable is returned when simulating a payment outcome
+// while another provider takes payments.
+var ErrSimulationUnavailable = errors.New("payments can only be simulated with the fake provider")
+
+// callbackTolerance is how old a callback's timestamp may be.
+const callbackTolerance = 5 * time.Minute
+
+// Fake is a deterministic Provider for local development and tests.  It
+// never moves money: payment, callback and refund ids are derived from the
+// order, outcome and refund ids, and callbacks are produced on demand by
+// Simulate.
+type Fake struct {
+	secret      string
+	baseURL     string
+	failRefunds bool
+}
+
+// NewFake constructs a Fake that signs callbacks with secret and sends
+// buyers to the checkout page under baseURL.  With failRefunds, every
+// refund is declined, to exercise the retry path.
+func NewFake(secret, baseURL string, failRefunds bool) *Fake {
+	return &Fake{secret: secret, baseURL: strings.TrimRight(baseURL, "/"), failRefunds: failRefunds}
+}
+
+// Name implements Provider.
+func (f *Fake) Name() string { return "fake" }
+
+// CreatePayment implements Provider.  The payment id is derived from the
+// order id, so asking twice returns the same payment.
+func (f *Fake) CreatePayment(ctx context.Context, req Request) (*Payment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.AmountCents <= 0 {
+		return nil, fmt.Errorf("fake payment: amount must be positive")
+	}
+	id := "fake_pay_" + digest(req.OrderID)
+	return &Payment{ID: id, CheckoutURL: f.baseURL + "/templates/checkout.html?payment=" + id}, nil
+}
+
+// Refund implements Provider.  The refund id is derived from the refund
+// request's id, so asking twice returns the same refund.
+func (f *Fake) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if f.failRefunds {
+		return nil, fmt.Errorf("fake refund: declined (simulated)")
+	}
+	if req.PaymentID == "" || req.AmountCents <= 0 {
+		return nil, fmt.Errorf("fake refund: payment and a positive amount are required")
+	}
+	return &Refund{ID: "fake_re_" + digest(req.RefundID)}, nil
+}
+
+// ParseCallback implements Provider.
+func (f *Fake) ParseCallback(body []byte, header http.Header) (*Callback, error) {
+	ts, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing timestamp", ErrInvalidCallback)
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > callbackTolerance || age < -callbackTolerance {
+		return nil, fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidCallback)
+	}
+	want := "v1=" + f.sign(ts, body)
+	if !hmac.Equal([]byte(header.Get(HeaderSignature)), []byte(want)) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidCallback)
+	}
+	var cb Callback
+	if err := json.Unmarshal(body, &cb); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCallback, err)
+	}
+	if cb.ID == "" || cb.PaymentID == "" || (cb.Status != StatusSucceeded && cb.Status != StatusFailed) {
+		return nil, fmt.Errorf("%w: missing id, payment_id or status", ErrInvalidCallback)
+	}
+	return &cb, nil
+}
+
+// Simulate returns the signed callback the fake provider would deliver for
+// a payment ending with status.  The callback id depends only on the
+// payment and status, so simulating the same outcome twice is a
+// redelivery.
+func (f *Fake) Simulate(paymentID, status string) (body []byte, header http.Header, err error) {
+	if status != StatusSucceeded && status != StatusFailed {
+		return nil, nil, fmt.Errorf("status must be %q or %q", StatusSucceeded, StatusFailed)
+	}
+	cb := Callback{ID: "fake_cb_" + digest(paymentID+"."+status), PaymentID: paymentID, Status: status}
+	if status == StatusFailed {
+		cb.Reason = "card declined (simulated)"
+	}
+	if body, err = json.Marshal(cb); err != nil {
+		return nil, nil, err
+	}
+	ts := time.Now().Unix()
+	header = http.Header{}
+	header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	header.Set(HeaderSignature, "v1="+f.sign(ts, body))
+	return body, header, nil
+}
+
+func (f *Fake) sign(timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(f.secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// digest returns a short stable id fragment for s.
+func digest(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:12])
+}
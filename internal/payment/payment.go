@@ -0,0 +1,108 @@
+// Package payment takes payments for orders through a pluggable Provider.
+// A provider starts a payment for an order and later reports its outcome
+// in a signed callback, and pays refunds back; Fake is a deterministic
+// provider for local development.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// ErrInvalidCallback is returned when a callback's signature does not
+// verify, or it is malformed or too old.
+var ErrInvalidCallback = errors.New("invalid payment callback")
+
+// Payment outcomes reported by callbacks.
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Request asks a provider to take one payment for an order.
+type Request struct {
+	OrderID     string
+	AmountCents int
+	Currency    string
+	Email       string
+	Description string
+}
+
+// Payment is a payment started by a provider.  The buyer completes it at
+// CheckoutURL.
+type Payment struct {
+	ID          string
+	CheckoutURL string
+}
+
+// RefundRequest asks a provider to pay back part or all of a payment.
+type RefundRequest struct {
+	RefundID    string
+	PaymentID   string
+	AmountCents int
+	Currency    string
+}
+
+// Refund is a refund accepted by a provider.
+type Refund struct {
+	ID string
+}
+
+// Callback is a provider's report of a payment's outcome.  ID identifies
+// the callback itself: a redelivered callback has the same ID.
+type Callback struct {
+	ID        string `json:"id"`
+	PaymentID string `json:"payment_id"`
+	Status    string `json:"status"` // StatusSucceeded or StatusFailed
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Provider takes payments.  Implementations must be safe for concurrent
+// use.
+type Provider interface {
+	// Name identifies the provider in stored orders.
+	Name() string
+	// CreatePayment starts a payment.  Calling it again for the same order
+	// must return the same payment, so a retry never charges twice.
+	CreatePayment(ctx context.Context, req Request) (*Payment, error)
+	// ParseCallback verifies and decodes a callback request, or returns
+	// an error wrapping ErrInvalidCallback.
+	ParseCallback(body []byte, header http.Header) (*Callback, error)
+	// Refund pays back a payment.  Calling it again for the same RefundID
+	// must return the same refund, so a retry never pays twice.
+	Refund(ctx context.Context, req RefundRequest) (*Refund, error)
+}
+
+// devFakeSecret signs fake callbacks in development.  It is public, so it is
+// only used when dev routes are enabled.
+const devFakeSecret = "fake-payment-secret"
+
+// NewFromEnv builds the Provider selected by PAYMENT_PROVIDER.  Only the
+// fake provider ships with the service; baseURL is where its checkout page
+// is served.  The fake provider needs FAKE_PAYMENT_SECRET unless dev is set,
+// in which case it falls back to a built-in development secret.
+func NewFromEnv(baseURL string, dev bool) (Provider, error) {
+	switch kind := getEnv("PAYMENT_PROVIDER", "fake"); kind {
+	case "fake":
+		secret := os.Getenv("FAKE_PAYMENT_SECRET")
+		if secret == "" {
+			if !dev {
+				return nil, fmt.Errorf("FAKE_PAYMENT_SECRET is required unless DEV_ROUTES=true")
+			}
+			secret = devFakeSecret
+		}
+		return NewFake(secret, baseURL, getEnv("FAKE_PAYMENT_FAIL_REFUNDS", "false") == "true"), nil
+	default:
+		return nil, fmt.Errorf("unknown PAYMENT_PROVIDER %q (want fake)", kind)
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrAllocationTooLarge is returned when an allocation would hold more
+// seats than the event has unbooked.
+var ErrAllocationTooLarge = errors.New("not enough unbooked seats to hold for the allocation")
+
+// ErrAllocationBelowIssued is returned when an allocation would shrink below
+// the comps already issued against it.
+var ErrAllocationBelowIssued = errors.New("allocation cannot be smaller than the comps already issued")
+
+// ErrAllocationExhausted is returned when issuing a comp against an
+// allocation with no seats left.
+var ErrAllocationExhausted = errors.New("allocation has no seats left")
+
+// AllocationRepository handles persistence for seat allocations and the
+// complimentary registrations issued against them.
+type AllocationRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAllocationRepository constructs an AllocationRepository.
+func NewAllocationRepository(db *pgxpool.Pool) *AllocationRepository {
+	return &AllocationRepository{db: db}
+}
+
+// allocationQuery selects an event's allocations, oldest first, with the
+// comps each has issued; $2, if not empty, selects one by name.
+const allocationQuery = `SELECT a.name, a.seats,
+	(SELECT COUNT(*) FROM registrations r WHERE r.event_id = a.event_id AND r.allocation = a.name)
+	FROM allocations a
+	WHERE a.event_id = $1 AND ($2 = '' OR a.name = $2)
+	ORDER BY a.created_at, a.name`
+
+// collectAllocations scans every row selected with allocationQuery.
+func collectAllocations(rows pgx.Rows) ([]model.Allocation, error) {
+	defer rows.Close()
+	var allocs []model.Allocation
+	for rows.Next() {
+		var a model.Allocation
+		if err := rows.Scan(&a.Name, &a.Seats, &a.Issued); err != nil {
+			return nil, fmt.Errorf("scan allocation: %w", err)
+		}
+		a.Remaining = max(a.Seats-a.Issued, 0)
+		allocs = append(allocs, a)
+	}
+	return allocs, rows.Err()
+}
+
+// getAllocation returns one allocation of an event, or ErrNotFound.
+func getAllocation(ctx context.Context, tx pgx.Tx, eventID, name string) (*model.Allocation, error) {
+	rows, err := tx.Query(ctx, allocationQuery, eventID, name)
+	if err != nil {
+		return nil, fmt.Errorf("get allocation: %w", err)
+	}
+	allocs, err := collectAllocations(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(allocs) == 0 {
+		return nil, ErrNotFound
+	}
+	return &allocs[0], nil
+}
+
+// ListByEvent returns an event's allocations, oldest first.
+func (r *AllocationRepository) ListByEvent(ctx context.Context, eventID string) ([]model.Allocation, error) {
+	rows, err := r.db.Query(ctx, allocationQuery, eventID, "")
+	if err != nil {
+		return nil, fmt.Errorf("list allocations: %w", err)
+	}
+	return collectAllocations(rows)
+}
+
+// Set creates an allocation of seats, or resizes an existing one, under
+// the event row lock.  The seats it holds come out of public availability
+// at once; it fails with ErrAllocationTooLarge if they are already booked.
+func (r *AllocationRepository) Set(ctx context.Context, eventID, name string, seats int) (*model.Allocation, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := lockEvent(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := getAllocation(ctx, tx, eventID, name)
+	switch {
+	case err == nil && seats < existing.Issued:
+		return nil, fmt.Errorf("%w: %d issued", ErrAllocationBelowIssued, existing.Issued)
+	case err != nil && !errors.Is(err, ErrNotFound):
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO allocations (event_id, name, seats) VALUES ($1, $2, $3)
+		 ON CONFLICT (event_id, name) DO UPDATE SET seats = EXCLUDED.seats`,
+		eventID, name, seats,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("save allocation: %w", err)
+	}
+	if err = refreshHeld(ctx, tx, event); err != nil {
+		return nil, err
+	}
+	alloc, err := getAllocation(ctx, tx, eventID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return alloc, nil
+}
+
+// Delete removes an allocation and releases its unissued seats to the
+// public.  Comps already issued against it are kept.
+func (r *AllocationRepository) Delete(ctx context.Context, eventID, name string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := lockEvent(ctx, tx, eventID)
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM allocations WHERE event_id = $1 AND name = $2`, eventID, name)
+	if err != nil {
+		return fmt.Errorf("delete allocation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	if err = refreshHeld(ctx, tx, event); err != nil {
+		return err
+	}
+	// The released seats may belong to the ballot waitlist.
+	if err = promoteWaitlist(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Comp issues a complimentary registration (reg's UserEmail, and Name,
+// Answers and SeatID if any) against an allocation.
+//
+// It is Book for a guest of the organizer: under the same event row lock it
+// checks for a duplicate and takes a seat with bookSeat, so the guest gets
+// the usual confirmation.  It skips everything that gates public sale
+// (approval, ballots, invite codes, sale phases) and takes its seat from
+// the allocation instead of public availability.  At reserved-seating
+// events the guest still needs a free seat: allocations hold a number of
+// seats, not particular ones.  allocation_remaining is
+// decremented before booked_count is incremented, so
+// within_sellable_capacity holds at every statement and a comp can never
+// exceed capacity.
+func (r *AllocationRepository) Comp(ctx context.Context, eventID, name string, reg *model.Registration) (*model.Registration, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := lockEvent(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	alloc, err := getAllocation(ctx, tx, eventID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var registered bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM registrations WHERE event_id = $1 AND user_email = $2)`,
+		eventID, reg.UserEmail,
+	).Scan(&registered)
+	if err != nil {
+		return nil, fmt.Errorf("check duplicate: %w", err)
+	}
+	if registered {
+		return nil, ErrAlreadyRegistered
+	}
+	if alloc.Remaining == 0 || event.AllocationRemaining == 0 {
+		return nil, ErrAllocationExhausted
+	}
+	if event.BookedCount >= event.SellableCapacity() {
+		return nil, ErrEventFull
+	}
+	seat := ""
+	if reg.SeatID != nil {
+		seat = *reg.SeatID
+	}
+	seats, err := claimSeats(ctx, tx, event, []string{seat})
+	if err != nil {
+		return nil, err
+	}
+	reg.SeatID = seats[0]
+
+	if _, err = tx.Exec(ctx,
+		`UPDATE events SET allocation_remaining = allocation_remaining - 1 WHERE id = $1`, eventID,
+	); err != nil {
+		return nil, fmt.Errorf("decrement allocation_remaining: %w", err)
+	}
+	event.AllocationRemaining--
+	reg.Allocation = &alloc.Name
+	if err = bookSeat(ctx, tx, event, reg); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return reg, nil
+}
+
+// lockEvent locks an event row FOR UPDATE inside tx, or returns ErrNotFound.
+func lockEvent(ctx context.Context, tx pgx.Tx, eventID string) (*model.Event, error) {
+	event, err := scanEvent(tx.QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock event row: %w", err)
+	}
+	return event, nil
+}
+
+// refreshHeld recomputes a locked event's allocation_remaining from its
+// allocations and the comps issued against them.  It returns
+// ErrAllocationTooLarge, before writing, if the held seats would no longer
+// fit beside the booked ones.
+func refreshHeld(ctx context.Context, tx pgx.Tx, event *model.Event) error {
+	var held int
+	err := tx.QueryRow(ctx,
+		`SELECT COALESCE(SUM(GREATEST(a.seats -
+		        (SELECT COUNT(*) FROM registrations r WHERE r.event_id = a.event_id AND r.allocation = a.name), 0)), 0)
+		 FROM allocations a
+		 WHERE a.event_id = $1`,
+		event.ID,
+	).Scan(&held)
+	if err != nil {
+		return fmt.Errorf("count held seats: %w", err)
+	}
+	if event.BookedCount+held > event.SellableCapacity() {
+		return fmt.Errorf("%w: %d seats unbooked", ErrAllocationTooLarge, event.SellableCapacity()-event.BookedCount)
+	}
+	if _, err = tx.Exec(ctx,
+		`UPDATE events SET allocation_remaining = $2 WHERE id = $1`, event.ID, held,
+	); err != nil {
+		return fmt.Errorf("update allocation_remaining: %w", err)
+	}
+	event.AllocationRemaining = held
+	return nil
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrApprovalRequired is returned by Book and BookGroup for events whose
+// registrations must be approved; the attendee applies instead.
+var ErrApprovalRequired = errors.New("event requires approval")
+
+// ErrAlreadyApplied is returned when an email applies while an earlier
+// application to the same event is still pending.
+var ErrAlreadyApplied = errors.New("an application for this email is already pending")
+
+// ErrApplicationDecided is returned when approving or rejecting an
+// application that is no longer pending.
+var ErrApplicationDecided = errors.New("application has already been decided")
+
+// ApplicationRepository handles persistence for applications to events that
+// require approval.
+type ApplicationRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewApplicationRepository constructs an ApplicationRepository.
+func NewApplicationRepository(db *pgxpool.Pool) *ApplicationRepository {
+	return &ApplicationRepository{db: db}
+}
+
+// applicationColumns is the column list matching scanApplication.
+const applicationColumns = `id, event_id, user_email, attendee_name, answers, status, registration_id, decided_at, created_at`
+
+// scanApplication scans a row selected with applicationColumns, followed by
+// any extra columns into extra.
+func scanApplication(row pgx.Row, extra ...any) (*model.Application, error) {
+	var a model.Application
+	dest := append([]any{&a.ID, &a.EventID, &a.UserEmail, &a.Name, &a.Answers, &a.Status,
+		&a.RegistrationID, &a.DecidedAt, &a.CreatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Apply files a pending application (UserEmail, and Name and Answers if
+// any) for an event.  It takes no seat.  The event row is locked as in
+// Book, so the registered and pending checks cannot race with an approval.
+func (r *ApplicationRepository) Apply(ctx context.Context, eventID string, app *model.Application) (*model.Application, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `SELECT 1 FROM events WHERE id = $1 FOR UPDATE`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("lock event row: %w", err)
+	}
+	// The checks run as a separate statement so that, after waiting for the
+	// lock, they see what the previous holder committed.
+	var exists, registered, pending bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM events WHERE id = $1),
+		        EXISTS (SELECT 1 FROM registrations WHERE event_id = $1 AND user_email = $2),
+		        EXISTS (SELECT 1 FROM applications
+		                WHERE event_id = $1 AND user_email = $2 AND status = 'pending')`,
+		eventID, app.UserEmail,
+	).Scan(&exists, &registered, &pending)
+	if err != nil {
+		return nil, fmt.Errorf("check application: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	if registered {
+		return nil, ErrAlreadyRegistered
+	}
+	if pending {
+		return nil, ErrAlreadyApplied
+	}
+
+	app.ID = uuid.New().String()
+	app.EventID = eventID
+	app.Status = model.ApplicationPending
+	app.CreatedAt = time.Now().UTC()
+	if app.Answers == nil {
+		app.Answers = model.Answers{}
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO applications (id, event_id, user_email, attendee_name, answers, status, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		app.ID, app.EventID, app.UserEmail, app.Name, app.Answers, app.Status, app.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert application: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return app, nil
+}
+
+// ListByEvent returns an event's applications, oldest first, optionally
+// only those in status.
+func (r *ApplicationRepository) ListByEvent(ctx context.Context, eventID, status string) ([]model.Application, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+applicationColumns+`
+		 FROM applications
+		 WHERE event_id = $1 AND ($2 = '' OR status = $2)
+		 ORDER BY created_at, id`,
+		eventID, status,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list applications: %w", err)
+	}
+	defer rows.Close()
+
+	var apps []model.Application
+	for rows.Next() {
+		app, err := scanApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan application: %w", err)
+		}
+		apps = append(apps, *app)
+	}
+	return apps, rows.Err()
+}
+
+// Approve turns a pending application into a registration.
+//
+// It is Book for an applicant chosen by an organizer: the event row is
+// locked first, then the application, and the capacity check runs under
+// that lock.  If the event filled up after the attendee applied, ErrEventFull
+// is returned and the application stays pending.  The seat is taken with
+// bookSeat, so the applicant gets the usual confirmation email and the
+// registration.created webhook fires.
+func (r *ApplicationRepository) Approve(ctx context.Context, eventID, applicationID string) (*model.Application, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := scanEvent(tx.QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock event row: %w", err)
+	}
+	app, err := lockApplication(ctx, tx, eventID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var registered bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM registrations WHERE event_id = $1 AND user_email = $2)`,
+		eventID, app.UserEmail,
+	).Scan(&registered)
+	if err != nil {
+		return nil, fmt.Errorf("check duplicate: %w", err)
+	}
+	if registered {
+		return nil, ErrAlreadyRegistered
+	}
+	if event.IsFull() {
+		return nil, ErrEventFull
+	}
+	// Applications carry no seat; reserved seating was turned on after
+	// they were filed.
+	if event.ReservedSeating {
+		return nil, ErrSeatRequired
+	}
+
+	reg := model.Registration{UserEmail: app.UserEmail, Name: app.Name, Answers: app.Answers}
+	if err = bookSeat(ctx, tx, event, &reg); err != nil {
+		return nil, err
+	}
+	if err = decideApplication(ctx, tx, app, model.ApplicationApproved, &reg.ID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return app, nil
+}
+
+// Reject declines a pending application and queues the rejection email in
+// the same transaction.
+func (r *ApplicationRepository) Reject(ctx context.Context, eventID, applicationID string) (*model.Application, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	app, err := lockApplication(ctx, tx, eventID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if err = decideApplication(ctx, tx, app, model.ApplicationRejected, nil); err != nil {
+		return nil, err
+	}
+
+	var eventName string
+	if err = tx.QueryRow(ctx, `SELECT name FROM events WHERE id = $1`, eventID).Scan(&eventName); err != nil {
+		return nil, fmt.Errorf("get event name: %w", err)
+	}
+	err = enqueueOutbox(ctx, tx, model.NotificationRejection, app.UserEmail, model.NotificationPayload{
+		EventID:        eventID,
+		EventName:      eventName,
+		RegistrationID: app.ID,
+		UserEmail:      app.UserEmail,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return app, nil
+}
+
+// lockApplication locks a pending application of the event inside the
+// caller's transaction.
+func lockApplication(ctx context.Context, tx pgx.Tx, eventID, applicationID string) (*model.Application, error) {
+	app, err := scanApplication(tx.QueryRow(ctx,
+		`SELECT `+applicationColumns+` FROM applications WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		applicationID, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock application: %w", err)
+	}
+	if app.Status != model.ApplicationPending {
+		return nil, ErrApplicationDecided
+	}
+	return app, nil
+}
+
+// decideApplication records the decision on a locked application.
+func decideApplication(ctx context.Context, tx pgx.Tx, app *model.Application, status string, registrationID *string) error {
+	now := time.Now().UTC()
+	_, err := tx.Exec(ctx,
+		`UPDATE applications SET status = $2, registration_id = $3, decided_at = $4 WHERE id = $1`,
+		app.ID, status, registrationID, now,
+	)
+	if err != nil {
+		return fmt.Errorf("decide application: %w", err)
+	}
+	app.Status = status
+	app.RegistrationID = registrationID
+	app.DecidedAt = &now
+	return nil
+}
@@ -0,0 +1,379 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrBallotOpen is returned by Book and BookGroup while an event's ballot
+// awaits its draw; attendees enter the ballot instead.
+var ErrBallotOpen = errors.New("event is allocated by ballot")
+
+// ErrBallotNotOpen is returned for entries outside the ballot's window.
+var ErrBallotNotOpen = errors.New("ballot is not accepting entries")
+
+// ErrAlreadyEntered is returned when the same email enters a ballot twice.
+var ErrAlreadyEntered = errors.New("email already entered this ballot")
+
+// ErrBallotNotClosed is returned when drawing a ballot whose entry window
+// has not closed yet.
+var ErrBallotNotClosed = errors.New("ballot entries are still open")
+
+// ErrBallotDrawn is returned when changing or drawing a ballot that has
+// already been drawn.
+var ErrBallotDrawn = errors.New("ballot has already been drawn")
+
+// ErrBallotUnavailable is returned when putting an event into ballot mode
+// that already has registrations, requires approval, has reserved seating
+// or sells paid tickets.
+var ErrBallotUnavailable = errors.New("ballot mode needs a free event without registrations, approval or reserved seating")
+
+// BallotRepository handles persistence for ballots, their entries and draws.
+type BallotRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBallotRepository constructs a BallotRepository.
+func NewBallotRepository(db *pgxpool.Pool) *BallotRepository {
+	return &BallotRepository{db: db}
+}
+
+// ballotColumns is the column list matching scanBallot.  The seed stays
+// hidden until the draw.
+const ballotColumns = `event_id, opens_at, closes_at, seed_hash,
+	CASE WHEN drawn_at IS NULL THEN '' ELSE seed END, drawn_at,
+	(SELECT COUNT(*) FROM ballot_entries WHERE ballot_entries.event_id = ballots.event_id),
+	created_at`
+
+func scanBallot(row pgx.Row) (*model.Ballot, error) {
+	var b model.Ballot
+	if err := row.Scan(&b.EventID, &b.OpensAt, &b.ClosesAt, &b.SeedHash, &b.Seed, &b.DrawnAt,
+		&b.EntryCount, &b.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Set puts an event into ballot mode with the given entry window, or moves
+// the window of a ballot not yet drawn.  seed and seedHash are only stored
+// for a new ballot; an existing ballot keeps the seed it committed to.
+func (r *BallotRepository) Set(ctx context.Context, eventID string, opensAt, closesAt time.Time, seed, seedHash string) (*model.Ballot, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := scanEvent(tx.QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock event row: %w", err)
+	}
+
+	var drawn *time.Time
+	err = tx.QueryRow(ctx, `SELECT drawn_at FROM ballots WHERE event_id = $1`, eventID).Scan(&drawn)
+	switch {
+	case err == nil && drawn != nil:
+		return nil, ErrBallotDrawn
+	case err == nil:
+		// Undrawn: no registrations can exist, only the window moves.
+	case errors.Is(err, pgx.ErrNoRows):
+		if event.BookedCount > 0 || event.PendingCount > 0 || event.RequiresApproval || event.ReservedSeating || event.IsPaid() {
+			return nil, ErrBallotUnavailable
+		}
+	default:
+		return nil, fmt.Errorf("get ballot: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO ballots (event_id, opens_at, closes_at, seed, seed_hash)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (event_id) DO UPDATE
+		 SET opens_at = EXCLUDED.opens_at, closes_at = EXCLUDED.closes_at`,
+		eventID, opensAt, closesAt, seed, seedHash,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("save ballot: %w", err)
+	}
+	ballot, err := scanBallot(tx.QueryRow(ctx,
+		`SELECT `+ballotColumns+` FROM ballots WHERE event_id = $1`, eventID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("get ballot: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return ballot, nil
+}
+
+// Get returns an event's ballot, with the published draw once drawn, or
+// ErrNotFound.
+func (r *BallotRepository) Get(ctx context.Context, eventID string) (*model.Ballot, error) {
+	ballot, err := scanBallot(r.db.QueryRow(ctx,
+		`SELECT `+ballotColumns+` FROM ballots WHERE event_id = $1`, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get ballot: %w", err)
+	}
+	if ballot.DrawnAt == nil {
+		return ballot, nil
+	}
+
+	rows, err := r.db.Query(ctx,
+		`SELECT draw_rank, id, ticket, outcome
+		 FROM ballot_entries
+		 WHERE event_id = $1
+		 ORDER BY draw_rank`,
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list draw results: %w", err)
+	}
+	ballot.Results, err = pgx.CollectRows(rows, pgx.RowToStructByPos[model.BallotResult])
+	if err != nil {
+		return nil, fmt.Errorf("list draw results: %w", err)
+	}
+	return ballot, nil
+}
+
+// Enter adds an entry (UserEmail, and Name and Answers if any) to an
+// event's ballot while its window is open at now.  The ballot row is
+// locked FOR SHARE, so entries run concurrently with each other but never
+// with the draw.
+func (r *BallotRepository) Enter(ctx context.Context, eventID string, entry *model.BallotEntry, now time.Time) (*model.BallotEntry, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	ballot, err := scanBallot(tx.QueryRow(ctx,
+		`SELECT `+ballotColumns+` FROM ballots WHERE event_id = $1 FOR SHARE`, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock ballot: %w", err)
+	}
+	if !ballot.IsOpen(now) {
+		return nil, ErrBallotNotOpen
+	}
+
+	entry.ID = uuid.New().String()
+	entry.EventID = eventID
+	entry.Outcome = model.BallotEntered
+	entry.CreatedAt = now.UTC()
+	if entry.Answers == nil {
+		entry.Answers = model.Answers{}
+	}
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO ballot_entries (id, event_id, user_email, attendee_name, answers, outcome, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (event_id, user_email) DO NOTHING`,
+		entry.ID, entry.EventID, entry.UserEmail, entry.Name, entry.Answers, entry.Outcome, entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert ballot entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrAlreadyEntered
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return entry, nil
+}
+
+// Due returns the events whose ballot closed at or before now and has not
+// been drawn.
+func (r *BallotRepository) Due(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT event_id FROM ballots WHERE drawn_at IS NULL AND closes_at <= $1 ORDER BY closes_at`, now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list due ballots: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list due ballots: %w", err)
+	}
+	return ids, nil
+}
+
+// Draw runs an event's ballot once its window has closed at now.
+//
+// The event row is locked FOR UPDATE as in Book, then the ballot row, which
+// waits out any entry still in flight.  Every entry gets its ticket
+// (model.BallotTicket) and the entries are ranked by model.BallotOrder.  Winners, in
+// rank order, take the seats left with bookSeat, so each gets the usual
+// confirmation email and webhook; the rest are waitlisted in rank order.
+// Entries, results and bookings commit together, so a draw happens exactly
+// once.
+func (r *BallotRepository) Draw(ctx context.Context, eventID string, now time.Time) (*model.Ballot, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := scanEvent(tx.QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock event row: %w", err)
+	}
+	var (
+		seed     string
+		closesAt time.Time
+		drawnAt  *time.Time
+	)
+	err = tx.QueryRow(ctx,
+		`SELECT seed, closes_at, drawn_at FROM ballots WHERE event_id = $1 FOR UPDATE`, eventID,
+	).Scan(&seed, &closesAt, &drawnAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock ballot: %w", err)
+	}
+	if drawnAt != nil {
+		return nil, ErrBallotDrawn
+	}
+	if now.Before(closesAt) {
+		return nil, ErrBallotNotClosed
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT id, user_email, attendee_name, answers FROM ballot_entries WHERE event_id = $1`, eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list ballot entries: %w", err)
+	}
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.BallotEntry, error) {
+		var e model.BallotEntry
+		err := row.Scan(&e.ID, &e.UserEmail, &e.Name, &e.Answers)
+		return e, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list ballot entries: %w", err)
+	}
+	byID := make(map[string]model.BallotEntry, len(entries))
+	ids := make([]string, len(entries))
+	for i, e := range entries {
+		byID[e.ID] = e
+		ids[i] = e.ID
+	}
+
+	var waitIDs, waitTickets []string
+	var waitRanks []int
+	for i, id := range model.BallotOrder(seed, ids) {
+		e, ticket, rank := byID[id], model.BallotTicket(seed, id), i+1
+		if event.IsFull() {
+			waitIDs = append(waitIDs, e.ID)
+			waitTickets = append(waitTickets, ticket)
+			waitRanks = append(waitRanks, rank)
+			continue
+		}
+		reg := model.Registration{UserEmail: e.UserEmail, Name: e.Name, Answers: e.Answers}
+		if err = bookSeat(ctx, tx, event, &reg); err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(ctx,
+			`UPDATE ballot_entries SET outcome = $2, draw_rank = $3, ticket = $4, registration_id = $5 WHERE id = $1`,
+			e.ID, model.BallotWon, rank, ticket, reg.ID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("record ballot winner: %w", err)
+		}
+	}
+	if len(waitIDs) > 0 {
+		_, err = tx.Exec(ctx,
+			`UPDATE ballot_entries b
+			 SET outcome = $2, draw_rank = w.rank, ticket = w.ticket
+			 FROM unnest($1::text[], $3::int[], $4::text[]) AS w(id, rank, ticket)
+			 WHERE b.id = w.id`,
+			waitIDs, model.BallotWaitlisted, waitRanks, waitTickets,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("record ballot waitlist: %w", err)
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE ballots SET drawn_at = $2 WHERE event_id = $1`, eventID, now); err != nil {
+		return nil, fmt.Errorf("mark ballot drawn: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return r.Get(ctx, eventID)
+}
+
+// promoteWaitlist gives free seats of a locked event to its ballot waitlist
+// in draw order, inside the caller's transaction.  Cancel and capacity
+// increases call it so a freed seat never skips the waitlist.
+func promoteWaitlist(ctx context.Context, tx pgx.Tx, event *model.Event) error {
+	for !event.IsFull() {
+		var entry model.BallotEntry
+		err := tx.QueryRow(ctx,
+			`SELECT id, user_email, attendee_name, answers
+			 FROM ballot_entries
+			 WHERE event_id = $1 AND outcome = 'waitlisted'
+			 ORDER BY draw_rank
+			 LIMIT 1
+			 FOR UPDATE`,
+			event.ID,
+		).Scan(&entry.ID, &entry.UserEmail, &entry.Name, &entry.Answers)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("next on waitlist: %w", err)
+		}
+
+		var registered bool
+		err = tx.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM registrations WHERE event_id = $1 AND user_email = $2)`,
+			event.ID, entry.UserEmail,
+		).Scan(&registered)
+		if err != nil {
+			return fmt.Errorf("check duplicate: %w", err)
+		}
+		var regID *string
+		if !registered { // already registered otherwise: the entry needs no seat
+			reg := model.Registration{UserEmail: entry.UserEmail, Name: entry.Name, Answers: entry.Answers}
+			if err = bookSeat(ctx, tx, event, &reg); err != nil {
+				return err
+			}
+			regID = &reg.ID
+		}
+		_, err = tx.Exec(ctx,
+			`UPDATE ballot_entries SET outcome = $2, registration_id = $3 WHERE id = $1`,
+			entry.ID, model.BallotPromoted, regID,
+		)
+		if err != nil {
+			return fmt.Errorf("promote ballot entry: %w", err)
+		}
+	}
+	return nil
+}
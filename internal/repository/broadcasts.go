@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// audienceSources maps each supported broadcast audience to a query for its
+// (event_id, user_email) rows: registrations, the ballot waitlist, or both.
+// UNION drops the duplicate rows of an address found in both.
+var audienceSources = map[string]string{
+	model.AudienceAll: `SELECT event_id, user_email FROM registrations
+		UNION
+		SELECT event_id, user_email FROM ballot_entries WHERE outcome = 'waitlisted'`,
+	model.AudienceConfirmed:  `SELECT event_id, user_email FROM registrations`,
+	model.AudienceCheckedIn:  `SELECT event_id, user_email FROM registrations WHERE checked_in_at IS NOT NULL`,
+	model.AudienceWaitlisted: `SELECT event_id, user_email FROM ballot_entries WHERE outcome = 'waitlisted'`,
+}
+
+// BroadcastRepository handles persistence for broadcast messages, their
+// recipients and unsubscribes.
+type BroadcastRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBroadcastRepository constructs a BroadcastRepository.
+func NewBroadcastRepository(db *pgxpool.Pool) *BroadcastRepository {
+	return &BroadcastRepository{db: db}
+}
+
+// Create stores a message, freezes its recipient list from the event's
+// registrations and waitlisted entries matching msg.Audience, and queues the
+// first send job of kind jobKind, all in one transaction.  Addresses that already unsubscribed from
+// the event are recorded as unsubscribed rather than pending.
+func (r *BroadcastRepository) Create(ctx context.Context, msg *model.BroadcastMessage, jobKind string) error {
+	source, ok := audienceSources[msg.Audience]
+	if !ok {
+		return fmt.Errorf("unsupported audience %q", msg.Audience)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	msg.ID = uuid.New().String()
+	msg.Status = model.BroadcastSending
+	msg.CreatedAt = time.Now().UTC()
+	_, err = tx.Exec(ctx,
+		`INSERT INTO broadcast_messages (id, event_id, subject, body, audience, status, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		msg.ID, msg.EventID, msg.Subject, msg.Body, msg.Audience, msg.Status, msg.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert broadcast message: %w", err)
+	}
+
+	// Two random UUIDs give the unsubscribe token ~244 bits of entropy
+	// without needing the pgcrypto extension.
+	_, err = tx.Exec(ctx,
+		`INSERT INTO broadcast_recipients (message_id, email, status, unsubscribe_token)
+		 SELECT $1, r.user_email,
+		        CASE WHEN u.email IS NULL THEN 'pending' ELSE 'unsubscribed' END,
+		        replace(gen_random_uuid()::text, '-', '') || replace(gen_random_uuid()::text, '-', '')
+		 FROM (`+source+`) r
+		 LEFT JOIN unsubscribes u ON u.event_id = r.event_id AND u.email = r.user_email
+		 WHERE r.event_id = $2`,
+		msg.ID, msg.EventID,
+	)
+	if err != nil {
+		return fmt.Errorf("insert broadcast recipients: %w", err)
+	}
+
+	if err = enqueueJob(ctx, tx, jobKind, map[string]string{"message_id": msg.ID}, msg.CreatedAt); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+const broadcastColumns = `m.id, m.event_id, m.subject, m.body, m.audience, m.status,
+	m.created_at, m.completed_at,
+	(SELECT COUNT(*) FILTER (WHERE status = 'pending')      FROM broadcast_recipients WHERE message_id = m.id),
+	(SELECT COUNT(*) FILTER (WHERE status = 'sent')         FROM broadcast_recipients WHERE message_id = m.id),
+	(SELECT COUNT(*) FILTER (WHERE status = 'failed')       FROM broadcast_recipients WHERE message_id = m.id),
+	(SELECT COUNT(*) FILTER (WHERE status = 'unsubscribed') FROM broadcast_recipients WHERE message_id = m.id)`
+
+func scanBroadcast(row pgx.Row) (*model.BroadcastMessage, error) {
+	var m model.BroadcastMessage
+	if err := row.Scan(&m.ID, &m.EventID, &m.Subject, &m.Body, &m.Audience, &m.Status,
+		&m.CreatedAt, &m.CompletedAt,
+		&m.Counts.Pending, &m.Counts.Sent, &m.Counts.Failed, &m.Counts.Unsubscribed); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Get returns a message with its delivery counts, or ErrNotFound.
+func (r *BroadcastRepository) Get(ctx context.Context, id string) (*model.BroadcastMessage, error) {
+	m, err := scanBroadcast(r.db.QueryRow(ctx,
+		`SELECT `+broadcastColumns+` FROM broadcast_messages m WHERE m.id = $1`,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get broadcast message: %w", err)
+	}
+	return m, nil
+}
+
+// ListByEvent returns an event's messages, newest first, with counts.
+func (r *BroadcastRepository) ListByEvent(ctx context.Context, eventID string) ([]model.BroadcastMessage, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+broadcastColumns+`
+		 FROM broadcast_messages m
+		 WHERE m.event_id = $1
+		 ORDER BY m.created_at DESC`,
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list broadcast messages: %w", err)
+	}
+	defer rows.Close()
+
+	var msgs []model.BroadcastMessage
+	for rows.Next() {
+		m, err := scanBroadcast(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan broadcast message: %w", err)
+		}
+		msgs = append(msgs, *m)
+	}
+	return msgs, rows.Err()
+}
+
+// ListRecipients returns the per-recipient delivery status of a message.
+func (r *BroadcastRepository) ListRecipients(ctx context.Context, messageID string) ([]model.BroadcastRecipient, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT message_id, email, status, attempts, last_error, sent_at, unsubscribe_token
+		 FROM broadcast_recipients
+		 WHERE message_id = $1
+		 ORDER BY email`,
+		messageID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list broadcast recipients: %w", err)
+	}
+	return collectRecipients(rows)
+}
+
+// NextBatch leases up to limit pending recipients of a message for lease and
+// returns them.  Recipients leased by another send job are skipped until
+// their lease runs out, so two jobs for one message never mail the same
+// address.  Pending recipients who unsubscribed since the message was
+// composed are marked unsubscribed first, so the sender always honors the
+// latest opt-outs.
+func (r *BroadcastRepository) NextBatch(ctx context.Context, messageID string, limit int, lease time.Duration) ([]model.BroadcastRecipient, error) {
+	_, err := r.db.Exec(ctx,
+		`UPDATE broadcast_recipients br
+		 SET status = 'unsubscribed'
+		 FROM broadcast_messages m, unsubscribes u
+		 WHERE br.message_id = $1
+		   AND br.status = 'pending'
+		   AND m.id = br.message_id
+		   AND u.event_id = m.event_id
+		   AND u.email = br.email`,
+		messageID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("apply unsubscribes: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx,
+		`WITH claimed AS (
+		     UPDATE broadcast_recipients
+		     SET locked_until = NOW() + make_interval(secs => $3)
+		     WHERE message_id = $1 AND email IN (
+		         SELECT email FROM broadcast_recipients
+		         WHERE message_id = $1 AND status = 'pending'
+		           AND (locked_until IS NULL OR locked_until < NOW())
+		         ORDER BY email
+		         LIMIT $2
+		         FOR UPDATE SKIP LOCKED
+		     )
+		     RETURNING message_id, email, status, attempts, last_error, sent_at, unsubscribe_token
+		 )
+		 SELECT * FROM claimed ORDER BY email`,
+		messageID, limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim broadcast batch: %w", err)
+	}
+	return collectRecipients(rows)
+}
+
+func collectRecipients(rows pgx.Rows) ([]model.BroadcastRecipient, error) {
+	defer rows.Close()
+	var recipients []model.BroadcastRecipient
+	for rows.Next() {
+		var rc model.BroadcastRecipient
+		if err := rows.Scan(&rc.MessageID, &rc.Email, &rc.Status, &rc.Attempts, &rc.LastError,
+			&rc.SentAt, &rc.UnsubscribeToken); err != nil {
+			return nil, fmt.Errorf("scan broadcast recipient: %w", err)
+		}
+		recipients = append(recipients, rc)
+	}
+	return recipients, rows.Err()
+}
+
+// MarkRecipientSent records a successful send and releases the recipient's
+// lease.
+func (r *BroadcastRepository) MarkRecipientSent(ctx context.Context, messageID, email string) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE broadcast_recipients
+		 SET status = 'sent', attempts = attempts + 1, last_error = '', sent_at = NOW(), locked_until = NULL
+		 WHERE message_id = $1 AND email = $2 AND status = 'pending'`,
+		messageID, email,
+	)
+	if err != nil {
+		return fmt.Errorf("mark recipient sent: %w", err)
+	}
+	return nil
+}
+
+// MarkRecipientFailed records a failed send and releases the recipient's
+// lease. The recipient stays pending for the next batch unless final is set.
+func (r *BroadcastRepository) MarkRecipientFailed(ctx context.Context, messageID, email string, sendErr error, final bool) error {
+	status := model.RecipientPending
+	if final {
+		status = model.RecipientFailed
+	}
+	_, err := r.db.Exec(ctx,
+		`UPDATE broadcast_recipients
+		 SET status = $3, attempts = attempts + 1, last_error = $4, locked_until = NULL
+		 WHERE message_id = $1 AND email = $2 AND status = 'pending'`,
+		messageID, email, status, sendErr.Error(),
+	)
+	if err != nil {
+		return fmt.Errorf("mark recipient failed: %w", err)
+	}
+	return nil
+}
+
+// Complete marks a message completed once no recipient is pending, and
+// reports whether the message is completed.  It stays sending while
+// recipients are pending under another job's lease.
+func (r *BroadcastRepository) Complete(ctx context.Context, messageID string) (bool, error) {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE broadcast_messages
+		 SET status = 'completed', completed_at = NOW()
+		 WHERE id = $1
+		   AND status = 'sending'
+		   AND NOT EXISTS (
+		       SELECT 1 FROM broadcast_recipients
+		       WHERE message_id = $1 AND status = 'pending')`,
+		messageID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("complete broadcast message: %w", err)
+	}
+	if tag.RowsAffected() == 1 {
+		return true, nil
+	}
+	var status string
+	if err := r.db.QueryRow(ctx, `SELECT status FROM broadcast_messages WHERE id = $1`, messageID).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, ErrNotFound
+		}
+		return false, fmt.Errorf("get broadcast message: %w", err)
+	}
+	return status == model.BroadcastCompleted, nil
+}
+
+// LookupUnsubscribe resolves an unsubscribe token to the event name and
+// address it belongs to.
+func (r *BroadcastRepository) LookupUnsubscribe(ctx context.Context, token string) (eventName, email string, err error) {
+	err = r.db.QueryRow(ctx,
+		`SELECT e.name, br.email
+		 FROM broadcast_recipients br
+		 JOIN broadcast_messages m ON m.id = br.message_id
+		 JOIN events e ON e.id = m.event_id
+		 WHERE br.unsubscribe_token = $1`,
+		token,
+	).Scan(&eventName, &email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", ErrNotFound
+		}
+		return "", "", fmt.Errorf("lookup unsubscribe token: %w", err)
+	}
+	return eventName, email, nil
+}
+
+// Unsubscribe opts the token's address out of the event's future broadcasts.
+// Repeating it is harmless.
+func (r *BroadcastRepository) Unsubscribe(ctx context.Context, token string) error {
+	tag, err := r.db.Exec(ctx,
+		`INSERT INTO unsubscribes (event_id, email)
+		 SELECT m.event_id, br.email
+		 FROM broadcast_recipients br
+		 JOIN broadcast_messages m ON m.id = br.message_id
+		 WHERE br.unsubscribe_token = $1
+		 ON CONFLICT (event_id, email) DO NOTHING`,
+		token,
+	)
+	if err != nil {
+		return fmt.Errorf("unsubscribe: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		// Either an unknown token or already unsubscribed; tell them apart.
+		if _, _, err := r.LookupUnsubscribe(ctx, token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
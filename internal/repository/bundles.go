@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrBundleRejected is returned, wrapped in a *BundleError, when any event
+// of a bundle cannot be booked.
+var ErrBundleRejected = errors.New("bundle registration rejected")
+
+// BundleError lists every event that stopped a bundle registration.  No
+// event was booked when it is returned.
+type BundleError struct {
+	Conflicts []model.BundleConflict
+}
+
+func (e *BundleError) Error() string {
+	parts := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		parts[i] = c.EventID + " " + c.Reason
+	}
+	return fmt.Sprintf("%s: %s", ErrBundleRejected, strings.Join(parts, ", "))
+}
+
+func (e *BundleError) Unwrap() error { return ErrBundleRejected }
+
+// BookBundle registers userEmail for every event in eventIDs or for none.
+//
+// Locking several event rows is where deadlocks come from: if one bundle
+// locks A then B while another locks B then A, each waits for the other
+// forever (Postgres detects the cycle and aborts one of them).  BookBundle
+// therefore locks the events one by one in ascending id order.  Every
+// transaction that locks more than one event (this and series edits)
+// acquires them in that order, and Book and Cancel lock a single event, so
+// no cycle can form.
+//
+// All events are locked and checked before any seat is taken, so the
+// returned *BundleError reports every event that was missing, full, already
+// booked by userEmail, invite-only, or only open to applications or ballot
+// entries, not just the first.  Invite codes are per event, so a bundle
+// cannot include invite-only events; nor does it take access codes, so an
+// event with sale phases is only booked in general sale (else not_on_sale),
+// or seats, so reserved-seating events are refused (reserved_seating).  A
+// bundle takes no payment either, so paid events are refused (paid).
+//
+// answers holds the registration answers per event id.
+func (r *RegistrationRepository) BookBundle(ctx context.Context, eventIDs []string, userEmail string, answers map[string]model.Answers) ([]model.Registration, error) {
+	ids := slices.Clone(eventIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var (
+		events    []*model.Event
+		conflicts []model.BundleConflict
+	)
+	for _, id := range ids {
+		event, err := scanEvent(tx.QueryRow(ctx,
+			`SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`, id,
+		))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				conflicts = append(conflicts, model.BundleConflict{EventID: id, Reason: model.BundleReasonNotFound})
+				continue
+			}
+			return nil, fmt.Errorf("lock event row: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	phases := make(map[string]*string, len(events))
+	for _, event := range events {
+		var registered bool
+		err = tx.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM registrations WHERE event_id = $1 AND user_email = $2)`,
+			event.ID, userEmail,
+		).Scan(&registered)
+		if err != nil {
+			return nil, fmt.Errorf("check duplicate: %w", err)
+		}
+		switch {
+		case event.BallotClosesAt != nil:
+			conflicts = append(conflicts, model.BundleConflict{EventID: event.ID, Reason: model.BundleReasonBallot})
+		case event.RequiresApproval:
+			conflicts = append(conflicts, model.BundleConflict{EventID: event.ID, Reason: model.BundleReasonRequiresApproval})
+		case event.Visibility == model.VisibilityInviteOnly:
+			conflicts = append(conflicts, model.BundleConflict{EventID: event.ID, Reason: model.BundleReasonInviteOnly})
+		case event.ReservedSeating:
+			conflicts = append(conflicts, model.BundleConflict{EventID: event.ID, Reason: model.BundleReasonReservedSeating})
+		case event.IsPaid():
+			conflicts = append(conflicts, model.BundleConflict{EventID: event.ID, Reason: model.BundleReasonPaid})
+		case registered:
+			conflicts = append(conflicts, model.BundleConflict{EventID: event.ID, Reason: model.BundleReasonAlreadyRegistered})
+		case event.IsFull():
+			conflicts = append(conflicts, model.BundleConflict{EventID: event.ID, Reason: model.BundleReasonFull})
+		default:
+			phase, err := choosePhase(ctx, tx, event, "", 1)
+			switch {
+			case errors.Is(err, ErrEventFull):
+				conflicts = append(conflicts, model.BundleConflict{EventID: event.ID, Reason: model.BundleReasonFull})
+			case errors.Is(err, ErrNotOnSale), errors.Is(err, ErrAccessCodeRequired):
+				conflicts = append(conflicts, model.BundleConflict{EventID: event.ID, Reason: model.BundleReasonNotOnSale})
+			case err != nil:
+				return nil, err
+			}
+			phases[event.ID] = phase
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &BundleError{Conflicts: conflicts}
+	}
+
+	regs := make([]model.Registration, 0, len(events))
+	for _, event := range events {
+		reg := model.Registration{UserEmail: userEmail, SalePhase: phases[event.ID], Answers: answers[event.ID]}
+		if err = bookSeat(ctx, tx, event, &reg); err != nil {
+			return nil, err
+		}
+		regs = append(regs, reg)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return regs, nil
+}
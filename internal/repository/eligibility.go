@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+)
+
+// ErrNotEligible is returned, wrapped in an *EligibilityError, when a
+// registration fails the event's eligibility rules.
+var ErrNotEligible = errors.New("not eligible to register for this event")
+
+// EligibilityError lists every eligibility rule a registration failed.
+// Nothing was booked when it is returned.
+type EligibilityError struct {
+	Reasons []model.EligibilityReason
+}
+
+func (e *EligibilityError) Error() string {
+	msgs := make([]string, len(e.Reasons))
+	for i, r := range e.Reasons {
+		msgs[i] = r.Message
+	}
+	return fmt.Sprintf("%s: %s", ErrNotEligible, strings.Join(msgs, "; "))
+}
+
+func (e *EligibilityError) Unwrap() error { return ErrNotEligible }
+
+// CountInScope returns how many events within a limit's scope email is
+// registered for: the occurrences of series key, or the events tagged key.
+func (r *RegistrationRepository) CountInScope(ctx context.Context, email, scope, key string) (int, error) {
+	var query string
+	switch scope {
+	case model.LimitScopeSeries:
+		query = `SELECT COUNT(*) FROM registrations r
+		         JOIN events e ON e.id = r.event_id
+		         WHERE r.user_email = $1 AND e.series_id = $2`
+	case model.LimitScopeTag:
+		query = `SELECT COUNT(*) FROM registrations r
+		         JOIN event_tags t ON t.event_id = r.event_id
+		         WHERE r.user_email = $1 AND t.tag = $2`
+	default:
+		return 0, fmt.Errorf("unknown limit scope %q", scope)
+	}
+	var n int
+	if err := r.db.QueryRow(ctx, query, email, key).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count registrations in %s: %w", scope, err)
+	}
+	return n, nil
+}
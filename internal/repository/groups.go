@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrGroupTooLarge is returned when a group registration has more attendees
+// than the event's max_group_size.
+var ErrGroupTooLarge = errors.New("group is larger than the event allows")
+
+// GroupConflictError lists the attendees of a group registration that are
+// already registered for the event.  It unwraps to ErrAlreadyRegistered;
+// nobody was booked when it is returned.
+type GroupConflictError struct {
+	Conflicts []model.GroupConflict
+}
+
+func (e *GroupConflictError) Error() string {
+	emails := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		emails[i] = c.Email
+	}
+	return fmt.Sprintf("%s: %s", ErrAlreadyRegistered, strings.Join(emails, ", "))
+}
+
+func (e *GroupConflictError) Unwrap() error { return ErrAlreadyRegistered }
+
+// BookGroup books every attendee for the event, or none of them.
+//
+// It is Book for several people under the same single row lock: the event
+// is locked once, the group size, duplicates and remaining capacity are
+// checked for the whole group, and only then is a seat taken for each
+// attendee.  The registrations share a new group id.  For invite-only
+// events inviteCode must have a use left for every attendee, for events
+// with sale phases the whole group is sold in one phase, and at
+// reserved-seating events every attendee needs a free seat of their own.
+func (r *RegistrationRepository) BookGroup(ctx context.Context, eventID string, attendees []model.GroupAttendee, inviteCode *string, accessCode string) (*model.GroupRegistration, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := scanEvent(tx.QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock event row: %w", err)
+	}
+	if event.BallotClosesAt != nil {
+		return nil, ErrBallotOpen
+	}
+	if event.RequiresApproval {
+		return nil, ErrApprovalRequired
+	}
+	if event.IsPaid() {
+		return nil, ErrPaymentRequired
+	}
+	if len(attendees) > event.MaxGroupSize {
+		return nil, fmt.Errorf("%w: at most %d attendees", ErrGroupTooLarge, event.MaxGroupSize)
+	}
+
+	emails := make([]string, len(attendees))
+	for i, a := range attendees {
+		emails[i] = a.Email
+	}
+	rows, err := tx.Query(ctx,
+		`SELECT user_email FROM registrations WHERE event_id = $1 AND user_email = ANY($2) ORDER BY user_email`,
+		eventID, emails,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("check duplicates: %w", err)
+	}
+	registered, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("check duplicates: %w", err)
+	}
+	if len(registered) > 0 {
+		conflict := &GroupConflictError{}
+		for _, email := range registered {
+			conflict.Conflicts = append(conflict.Conflicts, model.GroupConflict{
+				Email: email, Reason: model.GroupReasonAlreadyRegistered,
+			})
+		}
+		return nil, conflict
+	}
+
+	if event.Remaining() < len(attendees) {
+		return nil, fmt.Errorf("%w: %d seats left for %d attendees", ErrEventFull, event.Remaining(), len(attendees))
+	}
+	phase, err := choosePhase(ctx, tx, event, accessCode, len(attendees))
+	if err != nil {
+		return nil, err
+	}
+	if event.Visibility == model.VisibilityInviteOnly {
+		if err = redeemInvite(ctx, tx, event.ID, inviteCode, len(attendees)); err != nil {
+			return nil, err
+		}
+	} else {
+		inviteCode = nil
+	}
+	seatIDs := make([]string, len(attendees))
+	for i, a := range attendees {
+		seatIDs[i] = a.SeatID
+	}
+	seats, err := claimSeats(ctx, tx, event, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &model.GroupRegistration{GroupID: uuid.New().String()}
+	for i, a := range attendees {
+		reg := model.Registration{UserEmail: a.Email, Name: a.Name, GroupID: &group.GroupID,
+			InviteCode: inviteCode, SalePhase: phase, SeatID: seats[i], Answers: a.Answers}
+		if err = bookSeat(ctx, tx, event, &reg); err != nil {
+			return nil, err
+		}
+		group.Registrations = append(group.Registrations, reg)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return group, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrInviteRequired is returned when booking an invite-only event without
+// an invite code.
+var ErrInviteRequired = errors.New("event requires an invite code")
+
+// ErrInvalidInvite is returned when an invite code does not belong to the
+// event, was revoked, or has no uses left.
+var ErrInvalidInvite = errors.New("invite code is invalid, revoked or used up")
+
+// InviteRepository handles persistence for the invite codes of invite-only
+// events.
+type InviteRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewInviteRepository constructs an InviteRepository.
+func NewInviteRepository(db *pgxpool.Pool) *InviteRepository {
+	return &InviteRepository{db: db}
+}
+
+// inviteColumns is the column list matching scanInvite.
+const inviteColumns = `code, event_id, label, max_uses, used_count, revoked_at, created_at`
+
+// scanInvite scans a row selected with inviteColumns.
+func scanInvite(row pgx.Row) (*model.InviteCode, error) {
+	var c model.InviteCode
+	if err := row.Scan(&c.Code, &c.EventID, &c.Label, &c.MaxUses, &c.UsedCount,
+		&c.RevokedAt, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// collectInvites scans every row selected with inviteColumns.
+func collectInvites(rows pgx.Rows) ([]model.InviteCode, error) {
+	defer rows.Close()
+	var codes []model.InviteCode
+	for rows.Next() {
+		c, err := scanInvite(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan invite code: %w", err)
+		}
+		codes = append(codes, *c)
+	}
+	return codes, rows.Err()
+}
+
+// Create stores codes for an event, each usable maxUses times.  The codes
+// are inserted in one statement joined against the event, so nothing is
+// stored and ErrNotFound is returned if the event does not exist.
+func (r *InviteRepository) Create(ctx context.Context, eventID string, codes []string, maxUses int, label string) ([]model.InviteCode, error) {
+	rows, err := r.db.Query(ctx,
+		`INSERT INTO invite_codes (code, event_id, label, max_uses, created_at)
+		 SELECT c.code, e.id, $3, $4, $5
+		 FROM events e, unnest($2::text[]) WITH ORDINALITY AS c(code, n)
+		 WHERE e.id = $1
+		 ORDER BY c.n
+		 RETURNING `+inviteColumns,
+		eventID, codes, label, maxUses, time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert invite codes: %w", err)
+	}
+	created, err := collectInvites(rows)
+	if err != nil {
+		return nil, fmt.Errorf("insert invite codes: %w", err)
+	}
+	if len(created) == 0 {
+		return nil, ErrNotFound
+	}
+	return created, nil
+}
+
+// ListByEvent returns an event's invite codes, oldest first, revoked ones
+// included.
+func (r *InviteRepository) ListByEvent(ctx context.Context, eventID string) ([]model.InviteCode, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+inviteColumns+`
+		 FROM invite_codes
+		 WHERE event_id = $1
+		 ORDER BY created_at, code`,
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list invite codes: %w", err)
+	}
+	return collectInvites(rows)
+}
+
+// Revoke stops code from admitting further registrations.  Registrations
+// already made with it are kept.  Revoking a revoked code is a no-op.
+func (r *InviteRepository) Revoke(ctx context.Context, eventID, code string) (*model.InviteCode, error) {
+	invite, err := scanInvite(r.db.QueryRow(ctx,
+		`UPDATE invite_codes SET revoked_at = COALESCE(revoked_at, $3)
+		 WHERE event_id = $1 AND code = $2
+		 RETURNING `+inviteColumns,
+		eventID, code, time.Now().UTC(),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("revoke invite code: %w", err)
+	}
+	return invite, nil
+}
+
+// redeemInvite spends uses of code for a locked invite-only event inside the
+// caller's transaction.  The conditional UPDATE is the check: a code that is
+// missing, revoked or without enough uses left matches no row.
+func redeemInvite(ctx context.Context, tx pgx.Tx, eventID string, code *string, uses int) error {
+	if code == nil {
+		return ErrInviteRequired
+	}
+	tag, err := tx.Exec(ctx,
+		`UPDATE invite_codes SET used_count = used_count + $3
+		 WHERE code = $1 AND event_id = $2 AND revoked_at IS NULL AND used_count + $3 <= max_uses`,
+		*code, eventID, uses,
+	)
+	if err != nil {
+		return fmt.Errorf("redeem invite code: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInvalidInvite
+	}
+	return nil
+}
+
+// releaseInvite gives back the use a cancelled registration spent on code.
+func releaseInvite(ctx context.Context, tx pgx.Tx, code string) error {
+	if _, err := tx.Exec(ctx,
+		`UPDATE invite_codes SET used_count = used_count - 1 WHERE code = $1 AND used_count > 0`,
+		code,
+	); err != nil {
+		return fmt.Errorf("release invite code: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrJobNotRetryable is returned when retrying a job that has not failed.
+var ErrJobNotRetryable = errors.New("only failed jobs can be retried")
+
+// ErrLeaseLost is returned when recording the result of a job run whose
+// lease ran out, so that another worker may have claimed the job since.
+// The result is discarded; the job belongs to the newer claim.
+var ErrLeaseLost = errors.New("job lease lost")
+
+// JobRepository handles persistence for the background job queue.
+type JobRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewJobRepository constructs a JobRepository.
+func NewJobRepository(db *pgxpool.Pool) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+const jobColumns = `id, kind, payload, status, attempts, max_attempts, unique_key,
+	run_at, locked_until, last_error, created_at, finished_at`
+
+func scanJob(row pgx.Row) (*model.Job, error) {
+	var j model.Job
+	if err := row.Scan(&j.ID, &j.Kind, &j.Payload, &j.Status, &j.Attempts, &j.MaxAttempts, &j.UniqueKey,
+		&j.RunAt, &j.LockedUntil, &j.LastError, &j.CreatedAt, &j.FinishedAt); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// Enqueue adds a job. A non-empty uniqueKey makes the call idempotent: if a
+// job with that key already exists nothing is inserted and false is returned.
+func (r *JobRepository) Enqueue(ctx context.Context, kind string, payload any, runAt time.Time, maxAttempts int, uniqueKey string) (bool, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return false, fmt.Errorf("marshal job payload: %w", err)
+	}
+	var key *string
+	if uniqueKey != "" {
+		key = &uniqueKey
+	}
+	tag, err := r.db.Exec(ctx,
+		`INSERT INTO jobs (id, kind, payload, max_attempts, unique_key, run_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (unique_key) DO NOTHING`,
+		uuid.New().String(), kind, data, maxAttempts, key, runAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("enqueue job: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// enqueueJob adds a job inside the caller's transaction, so the job exists
+// if and only if the work it refers to was committed.
+func enqueueJob(ctx context.Context, tx pgx.Tx, kind string, payload any, runAt time.Time) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal job payload: %w", err)
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO jobs (id, kind, payload, run_at) VALUES ($1, $2, $3, $4)`,
+		uuid.New().String(), kind, data, runAt,
+	)
+	if err != nil {
+		return fmt.Errorf("enqueue job: %w", err)
+	}
+	return nil
+}
+
+// Claim leases the next runnable job, or returns nil if there is none.
+//
+// Runnable means queued and due, or running with an expired lease (its
+// worker died).  FOR UPDATE SKIP LOCKED lets every worker in every process
+// claim concurrently without ever handing the same job to two of them.
+func (r *JobRepository) Claim(ctx context.Context, lease time.Duration) (*model.Job, error) {
+	j, err := scanJob(r.db.QueryRow(ctx,
+		`UPDATE jobs
+		 SET status = 'running',
+		     attempts = attempts + 1,
+		     locked_until = NOW() + make_interval(secs => $1)
+		 WHERE id = (
+		     SELECT id FROM jobs
+		     WHERE (status = 'queued' AND run_at <= NOW())
+		        OR (status = 'running' AND locked_until < NOW())
+		     ORDER BY run_at
+		     LIMIT 1
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING `+jobColumns,
+		lease.Seconds(),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("claim job: %w", err)
+	}
+	return j, nil
+}
+
+// Complete marks a claimed job as succeeded.  The job's locked_until, as
+// Claim returned it, identifies the claim: if the lease has been taken over
+// by a later claim, nothing changes and ErrLeaseLost is returned.
+func (r *JobRepository) Complete(ctx context.Context, job *model.Job) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE jobs
+		 SET status = 'succeeded', last_error = '', locked_until = NULL, finished_at = NOW()
+		 WHERE id = $1 AND status = 'running' AND locked_until = $2`,
+		job.ID, job.LockedUntil,
+	)
+	if err != nil {
+		return fmt.Errorf("complete job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("complete job %s: %w", job.ID, ErrLeaseLost)
+	}
+	return nil
+}
+
+// Fail records a failed run of a claimed job. The job is re-queued for
+// retryAt, or marked failed for good when retryAt is nil.  As with Complete,
+// a claim that has been taken over changes nothing and returns ErrLeaseLost.
+func (r *JobRepository) Fail(ctx context.Context, job *model.Job, runErr error, retryAt *time.Time) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE jobs
+		 SET status = CASE WHEN $4::timestamptz IS NULL THEN 'failed' ELSE 'queued' END,
+		     last_error = $3,
+		     locked_until = NULL,
+		     run_at = COALESCE($4, run_at),
+		     finished_at = CASE WHEN $4::timestamptz IS NULL THEN NOW() END
+		 WHERE id = $1 AND status = 'running' AND locked_until = $2`,
+		job.ID, job.LockedUntil, runErr.Error(), retryAt,
+	)
+	if err != nil {
+		return fmt.Errorf("fail job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("fail job %s: %w", job.ID, ErrLeaseLost)
+	}
+	return nil
+}
+
+// Get returns a single job or ErrNotFound.
+func (r *JobRepository) Get(ctx context.Context, id string) (*model.Job, error) {
+	j, err := scanJob(r.db.QueryRow(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+	return j, nil
+}
+
+// List returns the most recent jobs, optionally filtered by status.
+func (r *JobRepository) List(ctx context.Context, status string, limit int) ([]model.Job, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+jobColumns+`
+		 FROM jobs
+		 WHERE $1 = '' OR status = $1
+		 ORDER BY created_at DESC
+		 LIMIT $2`,
+		status, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []model.Job
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan job: %w", err)
+		}
+		jobs = append(jobs, *j)
+	}
+	return jobs, rows.Err()
+}
+
+// Retry re-queues a failed job for immediate execution with a fresh attempt
+// budget.
+func (r *JobRepository) Retry(ctx context.Context, id string) (*model.Job, error) {
+	j, err := scanJob(r.db.QueryRow(ctx,
+		`UPDATE jobs
+		 SET status = 'queued', attempts = 0, run_at = NOW(), finished_at = NULL
+		 WHERE id = $1 AND status = 'failed'
+		 RETURNING `+jobColumns,
+		id,
+	))
+	if err == nil {
+		return j, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("retry job: %w", err)
+	}
+	if _, err := r.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return nil, ErrJobNotRetryable
+}
@@ -0,0 +1,534 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrPaymentRequired is returned by Book and BookGroup for events that sell
+// paid tickets; the attendee places an order instead.
+var ErrPaymentRequired = errors.New("event sells paid tickets")
+
+// ErrOrderPending is returned when an email places an order while an
+// earlier order for the same event is still awaiting payment.
+var ErrOrderPending = errors.New("an order for this email is already awaiting payment")
+
+// ErrPricingConflict is returned when a paid event would require approval
+// or have a ballot, which book seats without taking payment.
+var ErrPricingConflict = errors.New("paid tickets cannot be combined with approval or a ballot")
+
+// OrderRepository handles persistence for orders of paid tickets and the
+// payment callbacks that settle them.
+type OrderRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOrderRepository constructs an OrderRepository.
+func NewOrderRepository(db *pgxpool.Pool) *OrderRepository {
+	return &OrderRepository{db: db}
+}
+
+// orderColumns is the column list matching scanOrder.
+const orderColumns = `id, event_id, user_email, attendee_name, answers, invite_code, sale_phase, seat_id,
+	amount_cents, currency, status, provider, payment_id, checkout_url, registration_id, failure_reason,
+	expires_at, paid_at, created_at, updated_at`
+
+// scanOrder scans a row selected with orderColumns.
+func scanOrder(row pgx.Row) (*model.Order, error) {
+	var o model.Order
+	err := row.Scan(&o.ID, &o.EventID, &o.UserEmail, &o.Name, &o.Answers, &o.InviteCode, &o.SalePhase,
+		&o.SeatID, &o.AmountCents, &o.Currency, &o.Status, &o.Provider, &o.PaymentID, &o.CheckoutURL,
+		&o.RegistrationID, &o.FailureReason, &o.ExpiresAt, &o.PaidAt, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// Create places a pending order (UserEmail, Provider, and Name, Answers,
+// InviteCode and SeatID if any) for one ticket of a paid event, holding a
+// seat for it until hold has passed.  accessCode unlocks a presale as in
+// Book.
+//
+// The event row is locked first, exactly like Book, and the order passes
+// the same checks a booking does: duplicates (including another pending
+// order, ErrOrderPending), capacity, sale phase, invite code and reserved
+// seat.  Instead of booking, it raises pending_count, which the capacity
+// guard counts like booked_count, and captures the event's price.
+func (r *OrderRepository) Create(ctx context.Context, eventID string, order *model.Order, accessCode string, hold time.Duration) (*model.Order, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := lockEvent(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsPaid() {
+		return nil, fmt.Errorf("%w: tickets for this event are now free", ErrNotOnSale)
+	}
+
+	var registered, pending bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM registrations WHERE event_id = $1 AND user_email = $2),
+		        EXISTS (SELECT 1 FROM orders WHERE event_id = $1 AND user_email = $2 AND status = 'pending')`,
+		eventID, order.UserEmail,
+	).Scan(&registered, &pending)
+	if err != nil {
+		return nil, fmt.Errorf("check duplicate: %w", err)
+	}
+	if registered {
+		return nil, ErrAlreadyRegistered
+	}
+	if pending {
+		return nil, ErrOrderPending
+	}
+
+	if event.IsFull() {
+		return nil, ErrEventFull
+	}
+	if order.SalePhase, err = choosePhase(ctx, tx, event, accessCode, 1); err != nil {
+		return nil, err
+	}
+	if event.Visibility == model.VisibilityInviteOnly {
+		if err = redeemInvite(ctx, tx, event.ID, order.InviteCode, 1); err != nil {
+			return nil, err
+		}
+	} else {
+		order.InviteCode = nil
+	}
+	seat := ""
+	if order.SeatID != nil {
+		seat = *order.SeatID
+	}
+	seats, err := claimSeats(ctx, tx, event, []string{seat})
+	if err != nil {
+		return nil, err
+	}
+	order.SeatID = seats[0]
+
+	if _, err = tx.Exec(ctx,
+		`UPDATE events SET pending_count = pending_count + 1 WHERE id = $1`, event.ID,
+	); err != nil {
+		return nil, fmt.Errorf("increment pending_count: %w", err)
+	}
+
+	now := time.Now().UTC()
+	order.ID = uuid.New().String()
+	order.EventID = event.ID
+	order.AmountCents = event.PriceCents
+	order.Currency = event.Currency
+	order.Status = model.OrderPending
+	order.ExpiresAt = now.Add(hold)
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	if order.Answers == nil {
+		order.Answers = model.Answers{}
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO orders (id, event_id, user_email, attendee_name, answers, invite_code, sale_phase, seat_id,
+		                     amount_cents, currency, status, provider, expires_at, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`,
+		order.ID, order.EventID, order.UserEmail, order.Name, order.Answers, order.InviteCode, order.SalePhase,
+		order.SeatID, order.AmountCents, order.Currency, order.Status, order.Provider, order.ExpiresAt,
+		order.CreatedAt, order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert order: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return order, nil
+}
+
+// SetPayment records the provider's payment for an order and where the
+// buyer completes it, or returns ErrNotFound.
+func (r *OrderRepository) SetPayment(ctx context.Context, orderID, paymentID, checkoutURL string) (*model.Order, error) {
+	order, err := scanOrder(r.db.QueryRow(ctx,
+		`UPDATE orders SET payment_id = $2, checkout_url = $3, updated_at = NOW()
+		 WHERE id = $1
+		 RETURNING `+orderColumns,
+		orderID, paymentID, checkoutURL,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("set order payment: %w", err)
+	}
+	return order, nil
+}
+
+// Fail marks a pending order failed with reason and releases its hold, as
+// when the provider could not start its payment.  An order that is no
+// longer pending is returned unchanged.
+func (r *OrderRepository) Fail(ctx context.Context, orderID, reason string) (*model.Order, error) {
+	return r.end(ctx, orderID, model.OrderFailed, reason, nil)
+}
+
+// Due returns the pending orders whose hold ran out at or before now.
+func (r *OrderRepository) Due(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT id FROM orders WHERE status = 'pending' AND expires_at <= $1 ORDER BY expires_at`, now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list due orders: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list due orders: %w", err)
+	}
+	return ids, nil
+}
+
+// Expire marks a pending order whose hold ran out at or before now expired
+// and releases its hold.  Any other order is returned unchanged.
+func (r *OrderRepository) Expire(ctx context.Context, orderID string, now time.Time) (*model.Order, error) {
+	return r.end(ctx, orderID, model.OrderExpired, "payment was not received in time", &now)
+}
+
+// end closes a pending order with status and reason, for Fail and Expire.
+// With due set, only an order expiring at or before it is closed.
+//
+// The event row is locked before the order row, the order every other
+// write to orders uses, so the hold is released under the same lock that
+// took it.
+func (r *OrderRepository) end(ctx context.Context, orderID, status, reason string, due *time.Time) (*model.Order, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	order, err := lockOrder(ctx, tx, `id = $1`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != model.OrderPending || (due != nil && order.ExpiresAt.After(*due)) {
+		return order, nil
+	}
+	if err = releaseHold(ctx, tx, order); err != nil {
+		return nil, err
+	}
+	if order, err = setOrderStatus(ctx, tx, order.ID, status, reason, nil); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return order, nil
+}
+
+// ApplyPayment settles the order paid by a provider's payment, once per
+// callback: callbackID is recorded in payment_callbacks in the same
+// transaction, and a callback already recorded returns the order
+// unchanged.  It returns ErrNotFound if no order has the payment, which
+// may be a callback that arrived before SetPayment; the provider's retry
+// then finds it.
+//
+// A failed payment fails a pending order and releases its hold.  A
+// successful one releases the hold and books the seat with bookSeat, so the
+// attendee gets the usual confirmation email and webhook, and the order is
+// fulfilled with the registration.  A payment that succeeds after its
+// order expired or failed books a seat if one is still free; if none is,
+// or the attendee registered meanwhile, the order stays paid with the
+// reason, and a full refund is recorded for the refund worker to pay out.
+func (r *OrderRepository) ApplyPayment(ctx context.Context, provider, callbackID, paymentID string, succeeded bool, reason string) (*model.Order, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	order, err := lockOrder(ctx, tx, `provider = $1 AND payment_id = $2`, provider, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	outcome := "failed"
+	if succeeded {
+		outcome = "succeeded"
+	}
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO payment_callbacks (provider, id, payment_id, status) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT DO NOTHING`,
+		provider, callbackID, paymentID, outcome,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("record payment callback: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return order, nil
+	}
+
+	switch {
+	case !succeeded:
+		if order.Status == model.OrderPending {
+			if err = releaseHold(ctx, tx, order); err != nil {
+				return nil, err
+			}
+			if order, err = setOrderStatus(ctx, tx, order.ID, model.OrderFailed, reason, nil); err != nil {
+				return nil, err
+			}
+		}
+	case order.Status == model.OrderPending, order.Status == model.OrderExpired, order.Status == model.OrderFailed:
+		if order, err = fulfillOrder(ctx, tx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return order, nil
+}
+
+// fulfillOrder books the seat an order paid for, inside the caller's
+// transaction, which holds the order's event and order row locks (see
+// lockOrder).  A pending order's hold becomes the booking; an expired or
+// failed order, whose hold is gone, must find a free seat, room in a sale
+// phase open now, its reserved seat and a use of its invite code again.
+// Its phase is chosen afresh with the access code of the phase it was
+// ordered in, so a presale that has sold out or closed since passes the
+// order on to general sale, if that is open and has room.  If that fails, the order is
+// marked paid with the reason instead, and a full refund is recorded.
+func fulfillOrder(ctx context.Context, tx pgx.Tx, order *model.Order) (*model.Order, error) {
+	event, err := lockEvent(ctx, tx, order.EventID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	held := order.Status == model.OrderPending
+	if held {
+		if err = releaseHold(ctx, tx, order); err != nil {
+			return nil, err
+		}
+		event.PendingCount--
+	}
+
+	var registered bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM registrations WHERE event_id = $1 AND user_email = $2)`,
+		event.ID, order.UserEmail,
+	).Scan(&registered)
+	if err != nil {
+		return nil, fmt.Errorf("check duplicate: %w", err)
+	}
+	// spent tells whether the order holds a use of its invite code, which
+	// an order that cannot be booked gives back.
+	spent := held && order.InviteCode != nil
+	unbookable := func(reason error) (*model.Order, error) {
+		if spent {
+			if err := releaseInvite(ctx, tx, *order.InviteCode); err != nil {
+				return nil, err
+			}
+		}
+		order, err := setOrderStatus(ctx, tx, order.ID, model.OrderPaid, reason.Error(), &now)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = oweRefund(ctx, tx, order, model.RefundReasonUnbookable, 100); err != nil {
+			return nil, err
+		}
+		return order, nil
+	}
+	if registered {
+		return unbookable(ErrAlreadyRegistered)
+	}
+
+	reg := &model.Registration{
+		UserEmail:  order.UserEmail,
+		Name:       order.Name,
+		InviteCode: order.InviteCode,
+		SalePhase:  order.SalePhase,
+		SeatID:     order.SeatID,
+		Answers:    order.Answers,
+	}
+	if !held {
+		if event.IsFull() {
+			return unbookable(ErrEventFull)
+		}
+		var code string
+		if order.SalePhase != nil {
+			err = tx.QueryRow(ctx,
+				`SELECT COALESCE(code, '') FROM sale_phases WHERE event_id = $1 AND name = $2`,
+				event.ID, *order.SalePhase,
+			).Scan(&code)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("get sale phase: %w", err)
+			}
+		}
+		if reg.SalePhase, err = choosePhase(ctx, tx, event, code, 1); errors.Is(err, ErrEventFull) ||
+			errors.Is(err, ErrNotOnSale) || errors.Is(err, ErrAccessCodeRequired) {
+			return unbookable(err)
+		} else if err != nil {
+			return nil, err
+		}
+		if order.InviteCode != nil {
+			if err = redeemInvite(ctx, tx, event.ID, order.InviteCode, 1); errors.Is(err, ErrInvalidInvite) {
+				return unbookable(err)
+			} else if err != nil {
+				return nil, err
+			}
+			spent = true
+		}
+		if order.SeatID != nil {
+			if _, err = claimSeats(ctx, tx, event, []string{*order.SeatID}); errors.Is(err, ErrSeatTaken) ||
+				errors.Is(err, ErrSeatNotFound) || errors.Is(err, ErrSeatRequired) {
+				return unbookable(err)
+			} else if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err = bookSeat(ctx, tx, event, reg); err != nil {
+		return nil, err
+	}
+
+	order, err = scanOrder(tx.QueryRow(ctx,
+		`UPDATE orders
+		 SET status = 'fulfilled', registration_id = $2, sale_phase = $4, failure_reason = '', paid_at = $3, updated_at = $3
+		 WHERE id = $1
+		 RETURNING `+orderColumns,
+		order.ID, reg.ID, now, reg.SalePhase,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("fulfill order: %w", err)
+	}
+	return order, nil
+}
+
+// lockOrder locks the event of the order matching where, and then the
+// order, FOR UPDATE inside tx, or returns ErrNotFound.  The event comes
+// first, the order Book and Create lock it in, so holds are only ever
+// taken and released under the event row lock.
+func lockOrder(ctx context.Context, tx pgx.Tx, where string, args ...any) (*model.Order, error) {
+	var eventID string
+	err := tx.QueryRow(ctx, `SELECT event_id FROM orders WHERE `+where, args...).Scan(&eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get order: %w", err)
+	}
+	if _, err = tx.Exec(ctx, `SELECT 1 FROM events WHERE id = $1 FOR UPDATE`, eventID); err != nil {
+		return nil, fmt.Errorf("lock event row: %w", err)
+	}
+	order, err := scanOrder(tx.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders WHERE `+where+` FOR UPDATE`, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock order: %w", err)
+	}
+	return order, nil
+}
+
+// releaseHold gives back the seat a pending order holds, inside the
+// caller's transaction, which holds the event row lock.  Its reserved seat,
+// if any, is freed by the order leaving pending.
+func releaseHold(ctx context.Context, tx pgx.Tx, order *model.Order) error {
+	if _, err := tx.Exec(ctx,
+		`UPDATE events SET pending_count = pending_count - 1 WHERE id = $1`, order.EventID,
+	); err != nil {
+		return fmt.Errorf("decrement pending_count: %w", err)
+	}
+	return nil
+}
+
+// setOrderStatus moves an order to status with reason, stamping paid_at if
+// paidAt is set.  Orders that will not be booked give back their invite
+// code's use.
+func setOrderStatus(ctx context.Context, tx pgx.Tx, orderID, status, reason string, paidAt *time.Time) (*model.Order, error) {
+	order, err := scanOrder(tx.QueryRow(ctx,
+		`UPDATE orders
+		 SET status = $2, failure_reason = $3, paid_at = COALESCE($4, paid_at), updated_at = NOW()
+		 WHERE id = $1
+		 RETURNING `+orderColumns,
+		orderID, status, reason, paidAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("update order: %w", err)
+	}
+	if (status == model.OrderFailed || status == model.OrderExpired) && order.InviteCode != nil {
+		if err = releaseInvite(ctx, tx, *order.InviteCode); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// Get returns an order, or ErrNotFound.
+func (r *OrderRepository) Get(ctx context.Context, id string) (*model.Order, error) {
+	order, err := scanOrder(r.db.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get order: %w", err)
+	}
+	return order, nil
+}
+
+// ListByEvent returns an event's orders, oldest first, optionally only
+// those in status, or ErrNotFound.
+func (r *OrderRepository) ListByEvent(ctx context.Context, eventID, status string) ([]model.Order, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM events WHERE id = $1)`, eventID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	rows, err := r.db.Query(ctx,
+		`SELECT `+orderColumns+`
+		 FROM orders
+		 WHERE event_id = $1 AND ($2 = '' OR status = $2)
+		 ORDER BY created_at, id`,
+		eventID, status,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []model.Order
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan order: %w", err)
+		}
+		orders = append(orders, *o)
+	}
+	return orders, rows.Err()
+}
+
+// checkPricing verifies, inside the caller's transaction, that a created or
+// updated paid event neither requires approval nor has a ballot.
+func checkPricing(ctx context.Context, tx pgx.Tx, event *model.Event) error {
+	if !event.IsPaid() {
+		return nil
+	}
+	if event.RequiresApproval {
+		return ErrPricingConflict
+	}
+	var ballot bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM ballots WHERE event_id = $1)`, event.ID).Scan(&ballot); err != nil {
+		return fmt.Errorf("get ballot: %w", err)
+	}
+	if ballot {
+		return ErrPricingConflict
+	}
+	return nil
+}
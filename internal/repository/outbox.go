@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxRepository handles persistence for the notification outbox.
+type OutboxRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOutboxRepository constructs an OutboxRepository.
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// enqueueOutbox writes a notification inside the caller's transaction, so the
+// message commits or rolls back together with the change that produced it.
+func enqueueOutbox(ctx context.Context, tx pgx.Tx, kind, recipient string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal outbox payload: %w", err)
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO outbox (id, kind, recipient, payload, created_at)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		uuid.New().String(), kind, recipient, data, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("insert outbox message: %w", err)
+	}
+	return nil
+}
+
+// Claim leases up to limit due messages for delivery.
+//
+// The inner SELECT … FOR UPDATE SKIP LOCKED lets concurrent dispatchers pick
+// disjoint rows without blocking each other.  Pushing next_attempt_at forward
+// by lease acts as a visibility timeout: if this process dies mid-delivery the
+// row becomes due again once the lease expires, giving at-least-once delivery.
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error) {
+	rows, err := r.db.Query(ctx,
+		`UPDATE outbox
+		 SET attempts = attempts + 1,
+		     next_attempt_at = NOW() + make_interval(secs => $2)
+		 WHERE id IN (
+		     SELECT id FROM outbox
+		     WHERE status = 'pending' AND next_attempt_at <= NOW()
+		     ORDER BY next_attempt_at
+		     LIMIT $1
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING id, kind, recipient, payload, status, attempts, last_error, next_attempt_at, created_at`,
+		limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var msgs []model.OutboxMessage
+	for rows.Next() {
+		var m model.OutboxMessage
+		if err := rows.Scan(&m.ID, &m.Kind, &m.Recipient, &m.Payload, &m.Status,
+			&m.Attempts, &m.LastError, &m.NextAttemptAt, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, rows.Err()
+}
+
+// MarkSent records a successful delivery.
+func (r *OutboxRepository) MarkSent(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE outbox SET status = 'sent', last_error = '', sent_at = NOW() WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("mark outbox message sent: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt. The message is retried at retryAt,
+// or moved to the dead state when retryAt is nil.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id string, deliveryErr error, retryAt *time.Time) error {
+	var err error
+	if retryAt == nil {
+		_, err = r.db.Exec(ctx,
+			`UPDATE outbox SET status = 'dead', last_error = $2 WHERE id = $1`,
+			id, deliveryErr.Error(),
+		)
+	} else {
+		_, err = r.db.Exec(ctx,
+			`UPDATE outbox SET last_error = $2, next_attempt_at = $3 WHERE id = $1`,
+			id, deliveryErr.Error(), *retryAt,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("mark outbox message failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidQuery is returned for bad list parameters: a malformed cursor,
+// an out-of-range limit, or an invalid filter or sort.
+var ErrInvalidQuery = errors.New("invalid query")
+
+var errMalformedCursor = fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+
+// Cursor is a keyset position: the sort key of the last row returned (in
+// Postgres text form) and its id, which breaks ties between equal keys.
+// Sort names the ordering the cursor belongs to, so a cursor cannot be
+// replayed against a different one.
+type Cursor struct {
+	Sort string
+	Key  string
+	ID   string
+}
+
+// Encode returns the opaque string form handed to clients.
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal([3]string{c.Sort, c.Key, c.ID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a cursor produced by Encode for the given sort. An
+// empty string yields a nil cursor, meaning "from the start".
+func DecodeCursor(s, sort string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+	var parts [3]string
+	if err := json.Unmarshal(raw, &parts); err != nil || parts[1] == "" || parts[2] == "" {
+		return nil, errMalformedCursor
+	}
+	if parts[0] != sort {
+		return nil, fmt.Errorf("%w: cursor was issued for sort %q", ErrInvalidQuery, parts[0])
+	}
+	return &Cursor{Sort: parts[0], Key: parts[1], ID: parts[2]}, nil
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrNotOnSale is returned when booking an event with sale phases outside
+// all of them.
+var ErrNotOnSale = errors.New("tickets are not on sale")
+
+// ErrAccessCodeRequired is returned when only presales are open and no valid
+// access code was given.
+var ErrAccessCodeRequired = errors.New("a valid access code is required during presale")
+
+// ErrAllocationsExceedCapacity is returned when the allocations of an
+// event's sale phases add up to more than its capacity.
+var ErrAllocationsExceedCapacity = errors.New("phase allocations exceed the event's capacity")
+
+// phaseQuery selects an event's sale phases in order, with the seats each
+// has sold, counting those held by orders awaiting payment.
+const phaseQuery = `SELECT p.name, p.starts_at, p.ends_at, COALESCE(p.code, ''), p.allocation,
+	(SELECT COUNT(*) FROM registrations r WHERE r.event_id = p.event_id AND r.sale_phase = p.name) +
+	(SELECT COUNT(*) FROM orders o WHERE o.event_id = p.event_id AND o.sale_phase = p.name AND o.status = 'pending')
+	FROM sale_phases p
+	WHERE p.event_id = $1
+	ORDER BY p.starts_at, p.name`
+
+// collectPhases scans every row selected with phaseQuery.
+func collectPhases(rows pgx.Rows) ([]model.SalePhase, error) {
+	defer rows.Close()
+	var phases []model.SalePhase
+	for rows.Next() {
+		var p model.SalePhase
+		if err := rows.Scan(&p.Name, &p.StartsAt, &p.EndsAt, &p.Code, &p.Allocation, &p.Sold); err != nil {
+			return nil, fmt.Errorf("scan sale phase: %w", err)
+		}
+		phases = append(phases, p)
+	}
+	return phases, rows.Err()
+}
+
+// SalePhases returns an event's sale phases in start order, or ErrNotFound.
+func (r *EventRepository) SalePhases(ctx context.Context, eventID string) ([]model.SalePhase, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM events WHERE id = $1)`, eventID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	rows, err := r.db.Query(ctx, phaseQuery, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list sale phases: %w", err)
+	}
+	return collectPhases(rows)
+}
+
+// SetSalePhases replaces an event's sale phases under its row lock, so the
+// allocations are checked against the capacity Book sees.  Phases are keyed
+// by name: a phase kept under the same name keeps the seats it has sold.
+func (r *EventRepository) SetSalePhases(ctx context.Context, eventID string, phases []model.SalePhase) ([]model.SalePhase, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := scanEvent(tx.QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock event row: %w", err)
+	}
+	allocated := 0
+	for _, p := range phases {
+		if p.Allocation != nil {
+			allocated += *p.Allocation
+		}
+	}
+	if allocated > event.SellableCapacity() {
+		return nil, fmt.Errorf("%w: %d allocated, capacity %d", ErrAllocationsExceedCapacity, allocated, event.SellableCapacity())
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM sale_phases WHERE event_id = $1`, eventID); err != nil {
+		return nil, fmt.Errorf("clear sale phases: %w", err)
+	}
+	for _, p := range phases {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO sale_phases (event_id, name, starts_at, ends_at, code, allocation)
+			 VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)`,
+			eventID, p.Name, p.StartsAt, p.EndsAt, p.Code, p.Allocation,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("insert sale phase: %w", err)
+		}
+	}
+
+	rows, err := tx.Query(ctx, phaseQuery, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list sale phases: %w", err)
+	}
+	saved, err := collectPhases(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return saved, nil
+}
+
+// choosePhase picks the sale phase that sells seats of a locked event to an
+// attendee with code (which may be empty), and returns its name, or nil for
+// events without phases.
+//
+// Of the phases open now, the presale matching code is tried first and then
+// a general phase without a code.  A phase can sell what is left of its own
+// allocation, if any, and at most the seats remaining after every other
+// phase's hold (model.SalePhase.Held).  A phase that has ended holds
+// nothing, which is how presale leftovers roll into general sale.
+func choosePhase(ctx context.Context, tx pgx.Tx, event *model.Event, code string, seats int) (*string, error) {
+	rows, err := tx.Query(ctx, phaseQuery, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list sale phases: %w", err)
+	}
+	phases, err := collectPhases(rows)
+	if err != nil || len(phases) == 0 {
+		return nil, err
+	}
+
+	now := time.Now()
+	var presale, general *model.SalePhase
+	presaleOpen := false
+	for i := range phases {
+		p := &phases[i]
+		switch {
+		case !p.IsOpen(now):
+		case p.Code == "":
+			if general == nil {
+				general = p
+			}
+		default:
+			presaleOpen = true
+			if code != "" && p.Code == code && presale == nil {
+				presale = p
+			}
+		}
+	}
+	if presale == nil && general == nil {
+		if presaleOpen {
+			return nil, ErrAccessCodeRequired
+		}
+		return nil, ErrNotOnSale
+	}
+
+	for _, p := range []*model.SalePhase{presale, general} {
+		if p == nil {
+			continue
+		}
+		available := event.Remaining()
+		for i := range phases {
+			if q := &phases[i]; q.Name != p.Name {
+				available -= q.Held(now)
+			}
+		}
+		if p.Allocation != nil {
+			available = min(available, *p.Allocation-p.Sold)
+		}
+		if available >= seats {
+			return &p.Name, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no seats left in the phase on sale", ErrEventFull)
+}
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrRefundNotRetryable is returned when retrying a refund that has not
+// failed.
+var ErrRefundNotRetryable = errors.New("only failed refunds can be retried")
+
+// refundRuleQuery selects an event's refund rules, most days before first.
+const refundRuleQuery = `SELECT days_before, percent FROM refund_rules WHERE event_id = $1 ORDER BY days_before DESC`
+
+const refundColumns = `id, event_id, order_id, registration_id, reason, percent, amount_cents, currency,
+	status, provider_refund_id, attempts, last_error, next_attempt_at, refunded_at, created_at, updated_at`
+
+func scanRefund(row pgx.Row) (*model.Refund, error) {
+	var f model.Refund
+	if err := row.Scan(&f.ID, &f.EventID, &f.OrderID, &f.RegistrationID, &f.Reason, &f.Percent, &f.AmountCents, &f.Currency,
+		&f.Status, &f.ProviderRefundID, &f.Attempts, &f.LastError, &f.NextAttemptAt, &f.RefundedAt, &f.CreatedAt, &f.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// RefundPolicy returns an event's refund rules, most days before first, or
+// ErrNotFound.
+func (r *EventRepository) RefundPolicy(ctx context.Context, eventID string) ([]model.RefundRule, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM events WHERE id = $1)`, eventID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	rows, err := r.db.Query(ctx, refundRuleQuery, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list refund rules: %w", err)
+	}
+	return collectRefundRules(rows)
+}
+
+// SetRefundPolicy replaces an event's refund rules under its row lock, so a
+// cancellation sees either the old policy or the new one, never a mix.
+func (r *EventRepository) SetRefundPolicy(ctx context.Context, eventID string, rules []model.RefundRule) ([]model.RefundRule, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err = lockEvent(ctx, tx, eventID); err != nil {
+		return nil, err
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM refund_rules WHERE event_id = $1`, eventID); err != nil {
+		return nil, fmt.Errorf("clear refund rules: %w", err)
+	}
+	for _, rule := range rules {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO refund_rules (event_id, days_before, percent) VALUES ($1, $2, $3)`,
+			eventID, rule.DaysBefore, rule.Percent,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("insert refund rule: %w", err)
+		}
+	}
+
+	rows, err := tx.Query(ctx, refundRuleQuery, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list refund rules: %w", err)
+	}
+	saved, err := collectRefundRules(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return saved, nil
+}
+
+// collectRefundRules scans every row selected with refundRuleQuery.
+func collectRefundRules(rows pgx.Rows) ([]model.RefundRule, error) {
+	defer rows.Close()
+
+	var rules []model.RefundRule
+	for rows.Next() {
+		var rule model.RefundRule
+		if err := rows.Scan(&rule.DaysBefore, &rule.Percent); err != nil {
+			return nil, fmt.Errorf("scan refund rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// cancelOrder cancels the fulfilled order that paid for a registration
+// being cancelled, inside the caller's transaction, which holds the event
+// row lock, and records the refund its event's policy owes at now.  It
+// returns nil for registrations that were not paid for or are owed nothing.
+func cancelOrder(ctx context.Context, tx pgx.Tx, event *model.Event, registrationID string, now time.Time) (*model.Refund, error) {
+	order, err := scanOrder(tx.QueryRow(ctx,
+		`SELECT `+orderColumns+` FROM orders
+		 WHERE registration_id = $1 AND status = 'fulfilled'
+		 FOR UPDATE`,
+		registrationID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lock order: %w", err)
+	}
+	if order, err = setOrderStatus(ctx, tx, order.ID, model.OrderCancelled, "", nil); err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, refundRuleQuery, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list refund rules: %w", err)
+	}
+	rules, err := collectRefundRules(rows)
+	if err != nil {
+		return nil, err
+	}
+	return oweRefund(ctx, tx, order, model.RefundReasonCancelled, model.RefundPercent(rules, event.StartsAt, now))
+}
+
+// oweRefund records a refund of percent of what an order paid, inside the
+// caller's transaction, due at once.  Nothing is recorded, and nil is
+// returned, when that comes to less than a cent.
+func oweRefund(ctx context.Context, tx pgx.Tx, order *model.Order, reason string, percent int) (*model.Refund, error) {
+	amount := order.AmountCents * percent / 100
+	if amount <= 0 {
+		return nil, nil
+	}
+	refund, err := scanRefund(tx.QueryRow(ctx,
+		`INSERT INTO refunds (id, event_id, order_id, registration_id, reason, percent, amount_cents, currency)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 RETURNING `+refundColumns,
+		uuid.New().String(), order.EventID, order.ID, order.RegistrationID, reason, percent, amount, order.Currency,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("insert refund: %w", err)
+	}
+	return refund, nil
+}
+
+// ClaimRefund leases the pending refund with id, or when id is empty the
+// longest-due pending refund, for one attempt and returns it with the
+// order it pays back.  The lease pushes next_attempt_at ahead, so other
+// workers skip the refund, and a worker that dies mid-attempt leaves it to
+// be claimed again once the lease runs out.  Both are nil when nothing is
+// due.
+func (r *OrderRepository) ClaimRefund(ctx context.Context, id string, lease time.Duration) (*model.Refund, *model.Order, error) {
+	refund, err := scanRefund(r.db.QueryRow(ctx,
+		`UPDATE refunds
+		 SET attempts = attempts + 1,
+		     next_attempt_at = NOW() + make_interval(secs => $2),
+		     updated_at = NOW()
+		 WHERE id = (
+		     SELECT id FROM refunds
+		     WHERE status = 'pending' AND next_attempt_at <= NOW() AND ($1 = '' OR id = $1)
+		     ORDER BY next_attempt_at
+		     LIMIT 1
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING `+refundColumns,
+		id, lease.Seconds(),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("claim refund: %w", err)
+	}
+	order, err := r.Get(ctx, refund.OrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return refund, order, nil
+}
+
+// CompleteRefund marks a pending refund as paid back by the provider.
+func (r *OrderRepository) CompleteRefund(ctx context.Context, id, providerRefundID string) (*model.Refund, error) {
+	refund, err := scanRefund(r.db.QueryRow(ctx,
+		`UPDATE refunds
+		 SET status = 'succeeded', provider_refund_id = $2, last_error = '',
+		     refunded_at = NOW(), updated_at = NOW()
+		 WHERE id = $1 AND status = 'pending'
+		 RETURNING `+refundColumns,
+		id, providerRefundID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("complete refund: %w", err)
+	}
+	return refund, nil
+}
+
+// FailRefund records a failed attempt at a pending refund.  The refund is
+// due again at retryAt, or marked failed for good when retryAt is nil.
+func (r *OrderRepository) FailRefund(ctx context.Context, id string, attemptErr error, retryAt *time.Time) (*model.Refund, error) {
+	refund, err := scanRefund(r.db.QueryRow(ctx,
+		`UPDATE refunds
+		 SET status = CASE WHEN $3::timestamptz IS NULL THEN 'failed' ELSE status END,
+		     last_error = $2,
+		     next_attempt_at = COALESCE($3, next_attempt_at),
+		     updated_at = NOW()
+		 WHERE id = $1 AND status = 'pending'
+		 RETURNING `+refundColumns,
+		id, attemptErr.Error(), retryAt,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("fail refund: %w", err)
+	}
+	return refund, nil
+}
+
+// GetRefund returns a single refund or ErrNotFound.
+func (r *OrderRepository) GetRefund(ctx context.Context, id string) (*model.Refund, error) {
+	refund, err := scanRefund(r.db.QueryRow(ctx, `SELECT `+refundColumns+` FROM refunds WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get refund: %w", err)
+	}
+	return refund, nil
+}
+
+// ListRefunds returns an event's refunds, oldest first, optionally only
+// those in status, or ErrNotFound for an unknown event.
+func (r *OrderRepository) ListRefunds(ctx context.Context, eventID, status string) ([]model.Refund, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM events WHERE id = $1)`, eventID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	rows, err := r.db.Query(ctx,
+		`SELECT `+refundColumns+`
+		 FROM refunds
+		 WHERE event_id = $1 AND ($2 = '' OR status = $2)
+		 ORDER BY created_at`,
+		eventID, status,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list refunds: %w", err)
+	}
+	defer rows.Close()
+
+	var refunds []model.Refund
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan refund: %w", err)
+		}
+		refunds = append(refunds, *refund)
+	}
+	return refunds, rows.Err()
+}
+
+// RetryRefund makes a failed refund due again at once with a fresh attempt
+// budget.
+func (r *OrderRepository) RetryRefund(ctx context.Context, id string) (*model.Refund, error) {
+	refund, err := scanRefund(r.db.QueryRow(ctx,
+		`UPDATE refunds
+		 SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+		 WHERE id = $1 AND status = 'failed'
+		 RETURNING `+refundColumns,
+		id,
+	))
+	if err == nil {
+		return refund, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("retry refund: %w", err)
+	}
+	if _, err := r.GetRefund(ctx, id); err != nil {
+		return nil, err
+	}
+	return nil, ErrRefundNotRetryable
+}
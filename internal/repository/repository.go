@@ -23,6 +23,22 @@ var ErrEventFull = errors.New("event is fully booked")
 // ErrAlreadyRegistered is returned when the same email registers twice.
 var ErrAlreadyRegistered = errors.New("email already registered for this event")
 
+// ErrCapacityBelowBooked is returned when an update would shrink capacity
+// below the number of seats already booked or held by allocations.
+var ErrCapacityBelowBooked = errors.New("capacity cannot be lower than the number of booked and held seats")
+
+// ErrVenueNotFound is returned when an event references a venue that does
+// not exist.
+var ErrVenueNotFound = errors.New("venue not found")
+
+// ErrVenueCapacityExceeded is returned when an event's capacity is larger
+// than its venue's maximum capacity.
+var ErrVenueCapacityExceeded = errors.New("capacity exceeds the venue's maximum capacity")
+
+// ErrInvalidSchedule is returned when an update leaves ends_at without a
+// starts_at, or not after it.
+var ErrInvalidSchedule = errors.New("ends_at must be after starts_at")
+
 // EventRepository handles persistence for events.
 type EventRepository struct {
 	db *pgxpool.Pool
@@ -33,66 +49,318 @@ func NewEventRepository(db *pgxpool.Pool) *EventRepository {
 	return &EventRepository{db: db}
 }
 
-// Create inserts a new event and returns it with a generated UUID.
+// eventColumns is the column list matching scanEvent. It must be selected
+// from the events table under its own name (no alias), for the tags and
+// ballot subqueries.
+const eventColumns = `id, name, description, capacity, booked_count, starts_at, ends_at, venue_id,
+	ARRAY(SELECT tag FROM event_tags WHERE event_tags.event_id = events.id ORDER BY tag),
+	max_group_size, series_id, created_at, requires_approval,
+	(SELECT closes_at FROM ballots WHERE ballots.event_id = events.id AND drawn_at IS NULL),
+	visibility, allocation_remaining, oversell_percent, oversell_seats, reserved_seating, transfers_disabled,
+	price_cents, currency, pending_count`
+
+// scanEvent scans a row selected with eventColumns, followed by any extra
+// columns into extra.
+func scanEvent(row pgx.Row, extra ...any) (*model.Event, error) {
+	var e model.Event
+	dest := append([]any{&e.ID, &e.Name, &e.Description, &e.Capacity, &e.BookedCount,
+		&e.StartsAt, &e.EndsAt, &e.VenueID, &e.Tags, &e.MaxGroupSize, &e.SeriesID, &e.CreatedAt,
+		&e.RequiresApproval, &e.BallotClosesAt, &e.Visibility,
+		&e.AllocationRemaining, &e.OversellPercent, &e.OversellSeats, &e.ReservedSeating,
+		&e.TransfersDisabled, &e.PriceCents, &e.Currency, &e.PendingCount}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Create inserts a new event and its tags and returns it with a generated UUID.
 func (r *EventRepository) Create(ctx context.Context, req model.CreateEventRequest) (*model.Event, error) {
 	event := &model.Event{
-		ID:          uuid.New().String(),
-		Name:        req.Name,
-		Description: req.Description,
-		Capacity:    req.Capacity,
-		BookedCount: 0,
-		CreatedAt:   time.Now().UTC(),
-	}
-
-	_, err := r.db.Exec(ctx,
-		`INSERT INTO events (id, name, description, capacity, booked_count, created_at)
-		 VALUES ($1, $2, $3, $4, $5, $6)`,
-		event.ID, event.Name, event.Description, event.Capacity, event.BookedCount, event.CreatedAt,
-	)
+		ID:                uuid.New().String(),
+		Name:              req.Name,
+		Description:       req.Description,
+		Capacity:          req.Capacity,
+		BookedCount:       0,
+		StartsAt:          req.StartsAt,
+		EndsAt:            req.EndsAt,
+		VenueID:           req.VenueID,
+		Tags:              req.Tags,
+		MaxGroupSize:      req.MaxGroupSize,
+		RequiresApproval:  req.RequiresApproval,
+		Visibility:        req.Visibility,
+		OversellPercent:   req.OversellPercent,
+		OversellSeats:     req.OversellSeats,
+		ReservedSeating:   req.ReservedSeating,
+		TransfersDisabled: req.TransfersDisabled,
+		PriceCents:        req.PriceCents,
+		Currency:          req.Currency,
+		CreatedAt:         time.Now().UTC(),
+	}
+
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("insert event: %w", err)
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err = checkVenueCapacity(ctx, tx, event.VenueID, event.Capacity); err != nil {
+		return nil, err
+	}
+	if err = checkSeating(ctx, tx, event); err != nil {
+		return nil, err
+	}
+	if err = checkPricing(ctx, tx, event); err != nil {
+		return nil, err
+	}
+	if err = insertEvent(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
 	return event, nil
 }
 
-// List returns all events ordered by creation time descending.
-func (r *EventRepository) List(ctx context.Context) ([]model.Event, error) {
-	rows, err := r.db.Query(ctx,
-		`SELECT id, name, description, capacity, booked_count, created_at
-		 FROM events
-		 ORDER BY created_at DESC`,
+// insertEvent inserts event and its tags inside the caller's transaction.
+// The venue capacity check is left to the caller.
+func insertEvent(ctx context.Context, tx pgx.Tx, event *model.Event) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO events (id, name, description, capacity, booked_count, starts_at, ends_at, venue_id,
+		                     max_group_size, series_id, created_at, requires_approval, visibility,
+		                     oversell_percent, oversell_seats, reserved_seating, transfers_disabled,
+		                     price_cents, currency)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+		event.ID, event.Name, event.Description, event.Capacity, event.BookedCount,
+		event.StartsAt, event.EndsAt, event.VenueID, event.MaxGroupSize, event.SeriesID, event.CreatedAt,
+		event.RequiresApproval, event.Visibility, event.OversellPercent, event.OversellSeats, event.ReservedSeating,
+		event.TransfersDisabled, event.PriceCents, event.Currency,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("list events: %w", err)
-	}
-	defer rows.Close()
-
-	var events []model.Event
-	for rows.Next() {
-		var e model.Event
-		if err := rows.Scan(&e.ID, &e.Name, &e.Description, &e.Capacity, &e.BookedCount, &e.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scan event: %w", err)
-		}
-		events = append(events, e)
+		return fmt.Errorf("insert event: %w", err)
 	}
-	return events, rows.Err()
+	return setEventTags(ctx, tx, event.ID, event.Tags)
 }
 
 // GetByID returns a single event or ErrNotFound.
 func (r *EventRepository) GetByID(ctx context.Context, id string) (*model.Event, error) {
-	var e model.Event
-	err := r.db.QueryRow(ctx,
-		`SELECT id, name, description, capacity, booked_count, created_at
-		 FROM events WHERE id = $1`,
+	e, err := scanEvent(r.db.QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1`,
 		id,
-	).Scan(&e.ID, &e.Name, &e.Description, &e.Capacity, &e.BookedCount, &e.CreatedAt)
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
 		}
 		return nil, fmt.Errorf("get event: %w", err)
 	}
-	return &e, nil
+	return e, nil
+}
+
+// Questions returns an event's registration questions, or ErrNotFound.
+func (r *EventRepository) Questions(ctx context.Context, eventID string) ([]model.Question, error) {
+	var questions []model.Question
+	err := r.db.QueryRow(ctx, `SELECT questions FROM events WHERE id = $1`, eventID).Scan(&questions)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get questions: %w", err)
+	}
+	return questions, nil
+}
+
+// SetQuestions replaces an event's registration questions.  Answers already
+// given are kept as they are.
+func (r *EventRepository) SetQuestions(ctx context.Context, eventID string, questions []model.Question) error {
+	tag, err := r.db.Exec(ctx, `UPDATE events SET questions = $2 WHERE id = $1`, eventID, questions)
+	if err != nil {
+		return fmt.Errorf("set questions: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Eligibility returns an event's eligibility rules, or ErrNotFound.
+func (r *EventRepository) Eligibility(ctx context.Context, eventID string) (*model.EligibilityRules, error) {
+	var rules model.EligibilityRules
+	err := r.db.QueryRow(ctx, `SELECT eligibility FROM events WHERE id = $1`, eventID).Scan(&rules)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get eligibility rules: %w", err)
+	}
+	return &rules, nil
+}
+
+// SetEligibility replaces an event's eligibility rules.  Existing
+// registrations are not re-checked.
+func (r *EventRepository) SetEligibility(ctx context.Context, eventID string, rules model.EligibilityRules) error {
+	tag, err := r.db.Exec(ctx, `UPDATE events SET eligibility = $2 WHERE id = $1`, eventID, rules)
+	if err != nil {
+		return fmt.Errorf("set eligibility rules: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Update applies a partial update to an event under its row lock, so a
+// capacity change cannot race with Book.  Added seats go to the ballot
+// waitlist first.  The event.updated webhook is queued in the same
+// transaction.
+func (r *EventRepository) Update(ctx context.Context, id string, req model.UpdateEventRequest) (*model.Event, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := scanEvent(tx.QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`, id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock event row: %w", err)
+	}
+
+	if req.Name != nil {
+		event.Name = *req.Name
+	}
+	if req.Description != nil {
+		event.Description = *req.Description
+	}
+	if err = applyCapacity(event, req); err != nil {
+		return nil, err
+	}
+	if req.StartsAt != nil {
+		event.StartsAt = req.StartsAt
+	}
+	if req.EndsAt != nil {
+		event.EndsAt = req.EndsAt
+	}
+	if req.VenueID != nil {
+		event.VenueID = req.VenueID
+		if *req.VenueID == "" {
+			event.VenueID = nil
+		}
+	}
+	if req.Tags != nil {
+		event.Tags = *req.Tags
+	}
+	if req.MaxGroupSize != nil {
+		event.MaxGroupSize = *req.MaxGroupSize
+	}
+	if req.RequiresApproval != nil {
+		event.RequiresApproval = *req.RequiresApproval
+	}
+	if req.Visibility != nil {
+		event.Visibility = *req.Visibility
+	}
+	if req.ReservedSeating != nil {
+		event.ReservedSeating = *req.ReservedSeating
+	}
+	if req.TransfersDisabled != nil {
+		event.TransfersDisabled = *req.TransfersDisabled
+	}
+	if req.PriceCents != nil {
+		event.PriceCents = *req.PriceCents
+	}
+	if req.Currency != nil {
+		event.Currency = *req.Currency
+	}
+	if event.EndsAt != nil && (event.StartsAt == nil || !event.EndsAt.After(*event.StartsAt)) {
+		return nil, ErrInvalidSchedule
+	}
+	if err = checkVenueCapacity(ctx, tx, event.VenueID, event.Capacity); err != nil {
+		return nil, err
+	}
+	if err = checkSeating(ctx, tx, event); err != nil {
+		return nil, err
+	}
+	if err = checkPricing(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE events
+		 SET name = $2, description = $3, capacity = $4, starts_at = $5, ends_at = $6, venue_id = $7,
+		     max_group_size = $8, requires_approval = $9, visibility = $10,
+		     oversell_percent = $11, oversell_seats = $12, reserved_seating = $13, transfers_disabled = $14,
+		     price_cents = $15, currency = $16
+		 WHERE id = $1`,
+		event.ID, event.Name, event.Description, event.Capacity, event.StartsAt, event.EndsAt, event.VenueID,
+		event.MaxGroupSize, event.RequiresApproval, event.Visibility, event.OversellPercent, event.OversellSeats,
+		event.ReservedSeating, event.TransfersDisabled, event.PriceCents, event.Currency,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update event: %w", err)
+	}
+	if req.Tags != nil {
+		if err = setEventTags(ctx, tx, event.ID, event.Tags); err != nil {
+			return nil, err
+		}
+	}
+	if req.Capacity != nil || req.OversellPercent != nil || req.OversellSeats != nil {
+		if err = promoteWaitlist(ctx, tx, event); err != nil {
+			return nil, err
+		}
+	}
+	if err = enqueueWebhooks(ctx, tx, event.ID, model.WebhookEventUpdated, event); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return event, nil
+}
+
+// applyCapacity applies the capacity and oversell fields of a partial update
+// to a locked event.  Setting one oversell allowance clears the other.  It
+// returns ErrCapacityBelowBooked if the sellable capacity would no longer
+// cover the booked and held seats.
+func applyCapacity(event *model.Event, req model.UpdateEventRequest) error {
+	if req.Capacity != nil {
+		event.Capacity = *req.Capacity
+	}
+	if req.OversellPercent != nil {
+		event.OversellPercent = *req.OversellPercent
+		if event.OversellPercent > 0 {
+			event.OversellSeats = 0
+		}
+	}
+	if req.OversellSeats != nil {
+		event.OversellSeats = *req.OversellSeats
+		if event.OversellSeats > 0 {
+			event.OversellPercent = 0
+		}
+	}
+	if held := event.AllocationRemaining + event.PendingCount; event.BookedCount+held > event.SellableCapacity() {
+		return fmt.Errorf("%w: %d booked, %d held", ErrCapacityBelowBooked, event.BookedCount, held)
+	}
+	return nil
+}
+
+// registrationColumns is the column list matching scanRegistration.
+const registrationColumns = `id, event_id, user_email, attendee_name, group_id, invite_code, sale_phase, allocation,
+	seat_id, ticket_code, answers, checked_in_at, created_at`
+
+// scanRegistration scans a row selected with registrationColumns.
+func scanRegistration(row pgx.Row) (*model.Registration, error) {
+	var reg model.Registration
+	if err := row.Scan(&reg.ID, &reg.EventID, &reg.UserEmail, &reg.Name, &reg.GroupID, &reg.InviteCode,
+		&reg.SalePhase, &reg.Allocation, &reg.SeatID, &reg.TicketCode, &reg.Answers, &reg.CheckedInAt, &reg.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &reg, nil
 }
 
 // RegistrationRepository handles persistence for registrations.
@@ -133,18 +401,20 @@ func NewRegistrationRepository(db *pgxpool.Pool) *RegistrationRepository {
 //	time can read-then-write the capacity counter, eliminating the race.
 //
 // ─────────────────────────────────────────────────────────────────────────────
-func (r *RegistrationRepository) Book(ctx context.Context, eventID, userEmail string) (*model.Registration, error) {
+//
+// reg carries the attendee's email, name, answers and invite code; Book
+// fills in the rest.  accessCode unlocks a presale of an event with sale
+// phases.
+func (r *RegistrationRepository) Book(ctx context.Context, eventID string, reg *model.Registration, accessCode string) (*model.Registration, error) {
 	// Begin a transaction – all steps below are atomic.
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("begin transaction: %w", err)
 	}
-	// Ensure the transaction is always resolved.
-	defer func() {
-		if err != nil {
-			_ = tx.Rollback(ctx)
-		}
-	}()
+	// Ensure the transaction is always resolved.  Rollback is a no-op once
+	// Commit has succeeded, and it must also run on the early domain-error
+	// returns below (full, duplicate), which leave err nil.
+	defer func() { _ = tx.Rollback(ctx) }()
 
 	// ── Step 1: Acquire an exclusive row-level lock on the event. ──────────
 	//
@@ -152,26 +422,34 @@ func (r *RegistrationRepository) Book(ctx context.Context, eventID, userEmail st
 	// this row (with FOR UPDATE) until we COMMIT or ROLLBACK.  This is
 	// *pessimistic locking*: we assume contention will happen and prevent it
 	// upfront rather than detecting and retrying after the fact.
-	var capacity, bookedCount int
-	err = tx.QueryRow(ctx,
-		`SELECT capacity, booked_count
+	event, err := scanEvent(tx.QueryRow(ctx,
+		`SELECT `+eventColumns+`
 		 FROM events
 		 WHERE id = $1
 		 FOR UPDATE`,
 		eventID,
-	).Scan(&capacity, &bookedCount)
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
 		}
 		return nil, fmt.Errorf("lock event row: %w", err)
 	}
+	if event.BallotClosesAt != nil {
+		return nil, ErrBallotOpen
+	}
+	if event.RequiresApproval {
+		return nil, ErrApprovalRequired
+	}
+	if event.IsPaid() {
+		return nil, ErrPaymentRequired
+	}
 
 	// ── Step 2: Check for duplicate registration. ──────────────────────────
 	var dupCount int
 	err = tx.QueryRow(ctx,
 		`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND user_email = $2`,
-		eventID, userEmail,
+		eventID, reg.UserEmail,
 	).Scan(&dupCount)
 	if err != nil {
 		return nil, fmt.Errorf("check duplicate: %w", err)
@@ -181,36 +459,39 @@ func (r *RegistrationRepository) Book(ctx context.Context, eventID, userEmail st
 	}
 
 	// ── Step 3: Guard against overbooking. ────────────────────────────────
-	if bookedCount >= capacity {
+	if event.IsFull() {
 		return nil, ErrEventFull
 	}
-
-	// ── Step 4: Increment the counter atomically in the same transaction. ──
-	_, err = tx.Exec(ctx,
-		`UPDATE events SET booked_count = booked_count + 1 WHERE id = $1`,
-		eventID,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("increment booked_count: %w", err)
+	// Events with sale phases only sell in an open phase, within its share.
+	if reg.SalePhase, err = choosePhase(ctx, tx, event, accessCode, 1); err != nil {
+		return nil, err
 	}
-
-	// ── Step 5: Create the registration record. ───────────────────────────
-	reg := &model.Registration{
-		ID:        uuid.New().String(),
-		EventID:   eventID,
-		UserEmail: userEmail,
-		CreatedAt: time.Now().UTC(),
+	// Invite-only events also spend one use of the attendee's code; other
+	// events ignore any code given.
+	if event.Visibility == model.VisibilityInviteOnly {
+		if err = redeemInvite(ctx, tx, event.ID, reg.InviteCode, 1); err != nil {
+			return nil, err
+		}
+	} else {
+		reg.InviteCode = nil
 	}
-	_, err = tx.Exec(ctx,
-		`INSERT INTO registrations (id, event_id, user_email, created_at)
-		 VALUES ($1, $2, $3, $4)`,
-		reg.ID, reg.EventID, reg.UserEmail, reg.CreatedAt,
-	)
+	// Reserved-seating events also need the attendee's seat to be free.
+	seat := ""
+	if reg.SeatID != nil {
+		seat = *reg.SeatID
+	}
+	seats, err := claimSeats(ctx, tx, event, []string{seat})
 	if err != nil {
-		return nil, fmt.Errorf("insert registration: %w", err)
+		return nil, err
 	}
+	reg.SeatID = seats[0]
 
-	// ── Step 6: Commit – only now does any other goroutine see the change. ─
+	// ── Steps 4–7: Take the seat (see bookSeat). ──────────────────────────
+	if err = bookSeat(ctx, tx, event, reg); err != nil {
+		return nil, err
+	}
+
+	// ── Step 8: Commit – only now does any other goroutine see the change. ─
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
@@ -218,27 +499,274 @@ func (r *RegistrationRepository) Book(ctx context.Context, eventID, userEmail st
 	return reg, nil
 }
 
-// ListByEvent returns all registrations for a given event.
-func (r *RegistrationRepository) ListByEvent(ctx context.Context, eventID string) ([]model.Registration, error) {
+// RegistrationSort names the only ordering of registration lists; it is the
+// sort cursors for ListByEvent are decoded with.
+const RegistrationSort = "created"
+
+// ListByEvent returns an event's registrations oldest first, starting
+// strictly after the cursor (nil for the first page), and the cursor of the
+// following page (nil on the last one).  A limit of 0 returns every
+// remaining registration.
+func (r *RegistrationRepository) ListByEvent(ctx context.Context, eventID string, after *Cursor, limit int) ([]model.Registration, *Cursor, error) {
+	var afterKey, afterID *string
+	if after != nil {
+		afterKey, afterID = &after.Key, &after.ID
+	}
+	fetch := 0
+	if limit > 0 {
+		fetch = limit + 1 // one extra row tells whether another page follows
+	}
 	rows, err := r.db.Query(ctx,
-		`SELECT id, event_id, user_email, created_at
+		`SELECT `+registrationColumns+`
 		 FROM registrations
 		 WHERE event_id = $1
-		 ORDER BY created_at ASC`,
-		eventID,
+		   AND ($2::text IS NULL OR (created_at, id) > ($2::text::timestamptz, $3))
+		 ORDER BY created_at ASC, id ASC
+		 LIMIT NULLIF($4, 0)`,
+		eventID, afterKey, afterID, fetch,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("list registrations: %w", err)
+		return nil, nil, fmt.Errorf("list registrations: %w", err)
 	}
 	defer rows.Close()
 
 	var regs []model.Registration
 	for rows.Next() {
-		var reg model.Registration
-		if err := rows.Scan(&reg.ID, &reg.EventID, &reg.UserEmail, &reg.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scan registration: %w", err)
+		reg, err := scanRegistration(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scan registration: %w", err)
+		}
+		regs = append(regs, *reg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if limit == 0 || len(regs) <= limit {
+		return regs, nil, nil
+	}
+	regs = regs[:limit]
+	last := regs[limit-1]
+	return regs, &Cursor{Sort: RegistrationSort, Key: last.CreatedAt.Format(time.RFC3339Nano), ID: last.ID}, nil
+}
+
+// bookSeat takes one seat of a locked event for reg (UserEmail, and Name,
+// GroupID, InviteCode, SalePhase, Allocation, SeatID and Answers if any) inside the caller's transaction,
+// filling in its id, event, ticket code and timestamp.  The caller holds the event's row lock and has already
+// checked for duplicates and capacity, and claimed the seat if it is reserved.
+func bookSeat(ctx context.Context, tx pgx.Tx, event *model.Event, reg *model.Registration) error {
+	// ── Step 4: Increment the counter atomically in the same transaction. ──
+	_, err := tx.Exec(ctx,
+		`UPDATE events SET booked_count = booked_count + 1 WHERE id = $1`,
+		event.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("increment booked_count: %w", err)
+	}
+	event.BookedCount++
+
+	// ── Step 5: Create the registration record. ───────────────────────────
+	reg.ID = uuid.New().String()
+	reg.EventID = event.ID
+	reg.CreatedAt = time.Now().UTC()
+	if reg.Answers == nil {
+		reg.Answers = model.Answers{}
+	}
+	err = tx.QueryRow(ctx,
+		`INSERT INTO registrations (id, event_id, user_email, attendee_name, group_id, invite_code, sale_phase,
+		                            allocation, seat_id, answers, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		 RETURNING ticket_code`,
+		reg.ID, reg.EventID, reg.UserEmail, reg.Name, reg.GroupID, reg.InviteCode, reg.SalePhase,
+		reg.Allocation, reg.SeatID, reg.Answers, reg.CreatedAt,
+	).Scan(&reg.TicketCode)
+	if err != nil {
+		return fmt.Errorf("insert registration: %w", err)
+	}
+
+	// ── Step 6: Record the confirmation email in the outbox. ──────────────
+	//
+	// Written in the same transaction so the email exists if and only if the
+	// booking does; the dispatcher delivers it after COMMIT.
+	err = enqueueOutbox(ctx, tx, model.NotificationConfirmation, reg.UserEmail, model.NotificationPayload{
+		EventID:        event.ID,
+		EventName:      event.Name,
+		RegistrationID: reg.ID,
+		UserEmail:      reg.UserEmail,
+		TicketCode:     reg.TicketCode,
+	})
+	if err != nil {
+		return err
+	}
+
+	// ── Step 7: Fan out organizer webhooks, also inside the transaction. ──
+	if err = enqueueWebhooks(ctx, tx, event.ID, model.WebhookRegistrationCreated, model.NewWebhookRegistration(reg)); err != nil {
+		return err
+	}
+	if event.IsFull() {
+		if err = enqueueWebhooks(ctx, tx, event.ID, model.WebhookEventFull, event); err != nil {
+			return err
 		}
-		regs = append(regs, reg)
 	}
-	return regs, rows.Err()
+
+	return nil
+}
+
+// Cancel deletes a registration and releases its seat and session seats.
+//
+// The event row is locked first, exactly like Book, so the decrement of
+// booked_count is serialised with concurrent bookings.  The registration
+// and then its sessions are locked next, the order SessionRepository.Pick
+// uses.  The cancellation email and registration.cancelled webhook are
+// queued in the same transaction, the invite code's use is given back, a
+// comp's seat returns to its allocation, a reserved seat is freed with the
+// row, and any other freed seat goes to the ballot waitlist, if any.  A
+// paid registration's order is cancelled and the refund its event's policy
+// owes is recorded, all in the same transaction, so the seat and the money
+// cannot drift apart; the refund is returned for the caller to pay out.
+func (r *RegistrationRepository) Cancel(ctx context.Context, eventID, registrationID string) (*model.Registration, *model.Refund, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := scanEvent(tx.QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`,
+		eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("lock event row: %w", err)
+	}
+
+	if err = releaseSessions(ctx, tx, eventID, registrationID); err != nil {
+		return nil, nil, err
+	}
+	reg, err := scanRegistration(tx.QueryRow(ctx,
+		`DELETE FROM registrations
+		 WHERE id = $1 AND event_id = $2
+		 RETURNING `+registrationColumns,
+		registrationID, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("delete registration: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE events SET booked_count = booked_count - 1 WHERE id = $1`,
+		eventID,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decrement booked_count: %w", err)
+	}
+	event.BookedCount--
+	refund, err := cancelOrder(ctx, tx, event, reg.ID, time.Now().UTC())
+	if err != nil {
+		return nil, nil, err
+	}
+	if reg.InviteCode != nil {
+		if err = releaseInvite(ctx, tx, *reg.InviteCode); err != nil {
+			return nil, nil, err
+		}
+	}
+	if reg.Allocation != nil {
+		if err = refreshHeld(ctx, tx, event); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	payload := model.NotificationPayload{
+		EventID:        eventID,
+		EventName:      event.Name,
+		RegistrationID: reg.ID,
+		UserEmail:      reg.UserEmail,
+	}
+	if refund != nil {
+		payload.RefundCents, payload.RefundPercent, payload.RefundCurrency = refund.AmountCents, refund.Percent, refund.Currency
+	}
+	err = enqueueOutbox(ctx, tx, model.NotificationCancellation, reg.UserEmail, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = enqueueWebhooks(ctx, tx, eventID, model.WebhookRegistrationCancelled, model.NewWebhookRegistration(reg)); err != nil {
+		return nil, nil, err
+	}
+	if err = promoteWaitlist(ctx, tx, event); err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return reg, refund, nil
+}
+
+// CheckIn marks a registration as checked in at the door. Checking in twice
+// keeps the original time.
+func (r *RegistrationRepository) CheckIn(ctx context.Context, eventID, registrationID string) (*model.Registration, error) {
+	reg, err := scanRegistration(r.db.QueryRow(ctx,
+		`UPDATE registrations
+		 SET checked_in_at = COALESCE(checked_in_at, NOW())
+		 WHERE id = $1 AND event_id = $2
+		 RETURNING `+registrationColumns,
+		registrationID, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("check in registration: %w", err)
+	}
+	return reg, nil
+}
+
+// QueueDueReminders writes a reminder email to the outbox for every
+// registration whose event starts within lead and that has not been reminded
+// yet, marking each one so it is never queued twice.  It handles at most
+// limit registrations per call and returns how many it queued.
+//
+// Registrations made inside the lead window are skipped: their confirmation
+// email, sent moments earlier, already carries the start time.
+//
+// Selection, marking and the outbox INSERT are a single statement, so a crash
+// can neither lose nor duplicate reminders; SKIP LOCKED lets overlapping runs
+// on several instances split the work.
+func (r *RegistrationRepository) QueueDueReminders(ctx context.Context, lead time.Duration, limit int) (int, error) {
+	tag, err := r.db.Exec(ctx,
+		`WITH due AS (
+		     SELECT r.id, r.user_email, e.id AS event_id, e.name AS event_name
+		     FROM registrations r
+		     JOIN events e ON e.id = r.event_id
+		     WHERE r.reminder_queued_at IS NULL
+		       AND e.starts_at > NOW()
+		       AND e.starts_at <= NOW() + make_interval(secs => $1)
+		       AND r.created_at < e.starts_at - make_interval(secs => $1)
+		     LIMIT $2
+		     FOR UPDATE OF r SKIP LOCKED
+		 ), marked AS (
+		     UPDATE registrations
+		     SET reminder_queued_at = NOW()
+		     FROM due
+		     WHERE registrations.id = due.id
+		 )
+		 INSERT INTO outbox (id, kind, recipient, payload)
+		 SELECT gen_random_uuid()::text, $3, user_email,
+		        jsonb_build_object(
+		            'event_id', event_id,
+		            'event_name', event_name,
+		            'registration_id', id,
+		            'user_email', user_email)
+		 FROM due`,
+		lead.Seconds(), limit, model.NotificationReminder,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("queue reminders: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
 }
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+)
+
+// eventSortKey describes how one sort order ranks events: a key expression,
+// its Postgres type (used to cast the cursor key back), and the direction.
+// Ties are broken on id in the same direction, so a single row comparison
+// "(key, id) < (cursor key, cursor id)" is the keyset condition.
+type eventSortKey struct {
+	expr string
+	typ  string
+	desc bool
+}
+
+var eventSortKeys = map[string]eventSortKey{
+	model.EventSortNewest:    {expr: `created_at`, typ: `timestamptz`, desc: true},
+	model.EventSortSoonest:   {expr: `COALESCE(starts_at, 'infinity')`, typ: `timestamptz`, desc: false},
+	model.EventSortRemaining: {expr: `sellable_capacity - booked_count - allocation_remaining - pending_count`, typ: `integer`, desc: true},
+	model.EventSortRelevance: {expr: `ts_rank(search_vector, query)`, typ: `real`, desc: true},
+	model.EventSortDistance:  {expr: `geo.distance_km`, typ: `double precision`, desc: false},
+}
+
+// kmPerDegreeLat is the length of one degree of latitude, used to narrow a
+// radius search to a latitude band before computing exact distances.
+const kmPerDegreeLat = 111.045
+
+// List returns events matching search in its sort order, starting strictly
+// after the cursor (nil for the first page), and the cursor of the following
+// page (nil on the last one).  A limit of 0 returns every remaining event.
+// Only public events are listed.
+//
+// search.Sort must be one of model.EventSorts; relevance requires a query
+// and distance requires a search point.  The service validates all three.
+func (r *EventRepository) List(ctx context.Context, search model.EventSearch, after *Cursor, limit int) ([]model.Event, *Cursor, error) {
+	sort, ok := eventSortKeys[search.Sort]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown event sort %q", search.Sort)
+	}
+
+	var (
+		args  []any
+		conds []string
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	from := `events`
+	conds = append(conds, `visibility = 'public'`)
+	if search.Query != "" {
+		// websearch_to_tsquery accepts user input as typed: quoted phrases,
+		// "or", and -exclusions; it never raises a syntax error.
+		from = `events, websearch_to_tsquery('english', ` + arg(search.Query) + `) AS query`
+		conds = append(conds, `search_vector @@ query`)
+	}
+	if search.Near != nil {
+		// Great-circle distance by the haversine formula, so the search
+		// needs no PostGIS.  The LATERAL subquery drops events without a
+		// venue and exposes the distance for filtering, sorting and output.
+		lat, lng := arg(search.Near.Latitude), arg(search.Near.Longitude)
+		band := arg(search.RadiusKM / kmPerDegreeLat)
+		from += `, LATERAL (
+			SELECT 2 * 6371 * asin(least(1, sqrt(
+			           power(sin(radians(v.latitude - ` + lat + `) / 2), 2) +
+			           cos(radians(` + lat + `)) * cos(radians(v.latitude)) *
+			           power(sin(radians(v.longitude - ` + lng + `) / 2), 2)
+			       ))) AS distance_km
+			FROM venues v
+			WHERE v.id = events.venue_id
+			  AND v.latitude BETWEEN ` + lat + ` - ` + band + ` AND ` + lat + ` + ` + band + `
+		) AS geo`
+		conds = append(conds, `geo.distance_km <= `+arg(search.RadiusKM))
+	}
+	switch search.Availability {
+	case model.AvailabilityOpen:
+		conds = append(conds, `booked_count + allocation_remaining + pending_count < sellable_capacity`)
+	case model.AvailabilitySoldOut:
+		conds = append(conds, `booked_count + allocation_remaining + pending_count >= sellable_capacity`)
+	}
+	if search.StartsFrom != nil {
+		conds = append(conds, `starts_at >= `+arg(*search.StartsFrom))
+	}
+	if search.StartsBefore != nil {
+		conds = append(conds, `starts_at < `+arg(*search.StartsBefore))
+	}
+	if len(search.Tags) > 0 {
+		conds = append(conds, arg(search.Tags)+`::text[] <@
+			ARRAY(SELECT tag FROM event_tags WHERE event_tags.event_id = events.id)`)
+	}
+
+	dir, op := "ASC", ">"
+	if sort.desc {
+		dir, op = "DESC", "<"
+	}
+	if after != nil {
+		conds = append(conds, fmt.Sprintf(`(%s, id) %s (%s::text::%s, %s)`,
+			sort.expr, op, arg(after.Key), sort.typ, arg(after.ID)))
+	}
+
+	distance := `NULL::double precision`
+	if search.Near != nil {
+		distance = `geo.distance_km`
+	}
+	query := `SELECT ` + eventColumns + `, (` + sort.expr + `)::text, ` + distance + ` FROM ` + from
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, ` AND `)
+	}
+	query += fmt.Sprintf(` ORDER BY %s %s, id %s`, sort.expr, dir, dir)
+	if limit > 0 {
+		query += ` LIMIT ` + arg(limit+1) // one extra row tells whether another page follows
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list events: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		events []model.Event
+		keys   []string
+	)
+	for rows.Next() {
+		var (
+			key      string
+			distance *float64
+		)
+		e, err := scanEvent(rows, &key, &distance)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scan event: %w", err)
+		}
+		e.DistanceKM = distance
+		events = append(events, *e)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if limit == 0 || len(events) <= limit {
+		return events, nil, nil
+	}
+	events = events[:limit]
+	return events, &Cursor{Sort: search.Sort, Key: keys[limit-1], ID: events[limit-1].ID}, nil
+}
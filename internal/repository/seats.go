@@ -0,0 +1,374 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrNoSeatMap is returned when reserved seating is enabled for an event
+// whose venue has no seat map, or no venue at all.
+var ErrNoSeatMap = errors.New("reserved seating needs a venue with a seat map")
+
+// ErrNotEnoughSeats is returned when a reserved-seating event would sell
+// more seats than its venue's seat map has.
+var ErrNotEnoughSeats = errors.New("the seat map has fewer seats than the event sells")
+
+// ErrSeatingConflict is returned when reserved seating is combined with
+// approval or a ballot, which book seats without an attendee choosing one.
+var ErrSeatingConflict = errors.New("reserved seating cannot be combined with approval or a ballot")
+
+// ErrSeatsAssigned is returned when an event's venue would change while
+// registrations hold seats at the current one.
+var ErrSeatsAssigned = errors.New("registrations hold seats at the event's current venue")
+
+// ErrNotReservedSeating is returned when asking for the seats of an event
+// without reserved seating.
+var ErrNotReservedSeating = errors.New("event does not have reserved seating")
+
+// ErrSeatRequired is returned when booking a reserved-seating event without
+// a seat for every attendee.
+var ErrSeatRequired = errors.New("a seat must be chosen for every attendee")
+
+// ErrSeatNotFound is returned when a chosen seat is not in the seat map of
+// the event's venue.
+var ErrSeatNotFound = errors.New("seat not found at the event's venue")
+
+// ErrSeatTaken is returned, wrapped with the seats' names, when a chosen
+// seat is already held by another registration or an order awaiting
+// payment.
+var ErrSeatTaken = errors.New("seat is already taken")
+
+// ErrSeatInUse is returned, wrapped with the seats' names, when saving a
+// seat map would remove seats that registrations or pending orders hold.
+var ErrSeatInUse = errors.New("seats held by registrations cannot be removed from the seat map")
+
+// seatHolders selects the event_id and seat_id of every held seat: those of
+// registrations, and those of orders awaiting payment.
+const seatHolders = `(SELECT event_id, seat_id FROM registrations WHERE seat_id IS NOT NULL
+	UNION ALL
+	SELECT event_id, seat_id FROM orders WHERE status = 'pending' AND seat_id IS NOT NULL)`
+
+// seatQuery selects a venue's seats in map order; the last column tells
+// whether a registration or pending order for event $2 holds the seat.
+const seatQuery = `SELECT s.id, s.section, s.row_label, s.label, s.accessible,
+	EXISTS (SELECT 1 FROM ` + seatHolders + ` r WHERE r.event_id = $2 AND r.seat_id = s.id)
+	FROM venue_seats s
+	WHERE s.venue_id = $1
+	ORDER BY s.position`
+
+// collectSeatMap rebuilds the sections and rows of the seats selected with
+// seatQuery.  With status, each seat also says whether it is available.
+func collectSeatMap(rows pgx.Rows, status bool) (*model.SeatMap, error) {
+	defer rows.Close()
+	m := &model.SeatMap{Sections: []model.SeatSection{}}
+	for rows.Next() {
+		var (
+			section, row string
+			seat         model.Seat
+			taken        bool
+		)
+		if err := rows.Scan(&seat.ID, &section, &row, &seat.Label, &seat.Accessible, &taken); err != nil {
+			return nil, fmt.Errorf("scan seat: %w", err)
+		}
+		if status {
+			seat.Status = model.SeatAvailable
+			if taken {
+				seat.Status = model.SeatTaken
+			}
+		}
+		if n := len(m.Sections); n == 0 || m.Sections[n-1].Name != section {
+			m.Sections = append(m.Sections, model.SeatSection{Name: section})
+		}
+		sec := &m.Sections[len(m.Sections)-1]
+		if n := len(sec.Rows); n == 0 || sec.Rows[n-1].Label != row {
+			sec.Rows = append(sec.Rows, model.SeatRow{Label: row})
+		}
+		r := &sec.Rows[len(sec.Rows)-1]
+		r.Seats = append(r.Seats, seat)
+	}
+	return m, rows.Err()
+}
+
+// seatNames selects "section row+label" names, such as "Stalls B12", from
+// rows of section, row_label and label.
+func seatNames(rows pgx.Rows) ([]string, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (string, error) {
+		var section, rowLabel, label string
+		err := row.Scan(&section, &rowLabel, &label)
+		return section + " " + rowLabel + label, err
+	})
+}
+
+// SeatMap returns a venue's seat map, empty if it has none, or ErrNotFound.
+func (r *VenueRepository) SeatMap(ctx context.Context, venueID string) (*model.SeatMap, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM venues WHERE id = $1)`, venueID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("get venue: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	rows, err := r.db.Query(ctx, seatQuery, venueID, "")
+	if err != nil {
+		return nil, fmt.Errorf("list seats: %w", err)
+	}
+	return collectSeatMap(rows, false)
+}
+
+// SetSeatMap replaces a venue's seat map under the venue row lock.  Seats
+// are matched by section, row and label: those kept keep their ids and
+// registrations.  It fails with ErrSeatInUse if it would remove a seat a
+// registration or pending order holds, and with ErrNotEnoughSeats if a reserved-seating
+// event at the venue sells more seats than the new map has.
+func (r *VenueRepository) SetSeatMap(ctx context.Context, venueID string, m model.SeatMap) (*model.SeatMap, error) {
+	var sections, rowLabels, labels []string
+	var accessible []bool
+	for _, s := range m.Sections {
+		for _, row := range s.Rows {
+			for _, seat := range row.Seats {
+				sections = append(sections, s.Name)
+				rowLabels = append(rowLabels, row.Label)
+				labels = append(labels, seat.Label)
+				accessible = append(accessible, seat.Accessible)
+			}
+		}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Locking the venue row waits for event updates that are checking their
+	// seating against the current map (checkSeating).
+	if err = tx.QueryRow(ctx, `SELECT id FROM venues WHERE id = $1 FOR UPDATE`, venueID).Scan(&venueID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock venue row: %w", err)
+	}
+
+	// The seats to remove are locked before checking whether they are held,
+	// so a booking that has already chosen one (claimSeats) commits first.
+	rows, err := tx.Query(ctx,
+		`SELECT id FROM venue_seats
+		 WHERE venue_id = $1
+		   AND (section, row_label, label) NOT IN (SELECT * FROM unnest($2::text[], $3::text[], $4::text[]))
+		 FOR UPDATE`,
+		venueID, sections, rowLabels, labels,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("lock removed seats: %w", err)
+	}
+	removed, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("lock removed seats: %w", err)
+	}
+	if len(removed) > 0 {
+		rows, err = tx.Query(ctx,
+			`SELECT s.section, s.row_label, s.label FROM venue_seats s
+			 WHERE s.id = ANY($1) AND EXISTS (SELECT 1 FROM `+seatHolders+` r WHERE r.seat_id = s.id)
+			 ORDER BY s.position`,
+			removed,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("check held seats: %w", err)
+		}
+		held, err := seatNames(rows)
+		if err != nil {
+			return nil, fmt.Errorf("check held seats: %w", err)
+		}
+		if len(held) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrSeatInUse, strings.Join(held, ", "))
+		}
+		if _, err = tx.Exec(ctx, `DELETE FROM venue_seats WHERE id = ANY($1)`, removed); err != nil {
+			return nil, fmt.Errorf("delete seats: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO venue_seats (id, venue_id, section, row_label, label, accessible, position)
+		 SELECT gen_random_uuid()::text, $1, s.section, s.row_label, s.label, s.accessible, s.position
+		 FROM unnest($2::text[], $3::text[], $4::text[], $5::bool[])
+		      WITH ORDINALITY AS s(section, row_label, label, accessible, position)
+		 ON CONFLICT (venue_id, section, row_label, label) DO UPDATE
+		 SET accessible = EXCLUDED.accessible, position = EXCLUDED.position`,
+		venueID, sections, rowLabels, labels, accessible,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("save seats: %w", err)
+	}
+
+	rows, err = tx.Query(ctx,
+		`SELECT name FROM events
+		 WHERE venue_id = $1 AND reserved_seating AND sellable_capacity > $2
+		 ORDER BY name`,
+		venueID, len(labels),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("check reserved-seating events: %w", err)
+	}
+	short, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("check reserved-seating events: %w", err)
+	}
+	if len(short) > 0 {
+		return nil, fmt.Errorf("%w: %d seats for %s", ErrNotEnoughSeats, len(labels), strings.Join(short, ", "))
+	}
+
+	rows, err = tx.Query(ctx, seatQuery, venueID, "")
+	if err != nil {
+		return nil, fmt.Errorf("list seats: %w", err)
+	}
+	saved, err := collectSeatMap(rows, false)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return saved, nil
+}
+
+// Seats returns the seat map of a reserved-seating event's venue with the
+// status of each seat for the event, ErrNotReservedSeating for other
+// events, or ErrNotFound.
+func (r *EventRepository) Seats(ctx context.Context, eventID string) (*model.SeatMap, error) {
+	var (
+		reserved bool
+		venueID  *string
+	)
+	err := r.db.QueryRow(ctx, `SELECT reserved_seating, venue_id FROM events WHERE id = $1`, eventID).Scan(&reserved, &venueID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	if !reserved || venueID == nil {
+		return nil, ErrNotReservedSeating
+	}
+	rows, err := r.db.Query(ctx, seatQuery, *venueID, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("list seats: %w", err)
+	}
+	return collectSeatMap(rows, true)
+}
+
+// checkSeating verifies, inside the caller's transaction, that a created or
+// updated event can seat everyone it sells to.  Registrations and pending
+// orders holding seats pin the event to its venue.  With reserved seating the event needs a
+// venue whose seat map has at least its sellable capacity, read under a
+// share lock on the venue so SetSeatMap cannot shrink the map before
+// commit, and it cannot require approval or have a ballot.
+func checkSeating(ctx context.Context, tx pgx.Tx, event *model.Event) error {
+	var elsewhere bool
+	err := tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM `+seatHolders+` r JOIN venue_seats s ON s.id = r.seat_id
+		                WHERE r.event_id = $1 AND s.venue_id IS DISTINCT FROM $2)`,
+		event.ID, event.VenueID,
+	).Scan(&elsewhere)
+	if err != nil {
+		return fmt.Errorf("check seated registrations: %w", err)
+	}
+	if elsewhere {
+		return ErrSeatsAssigned
+	}
+	if !event.ReservedSeating {
+		return nil
+	}
+
+	var ballot bool
+	if err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM ballots WHERE event_id = $1)`, event.ID).Scan(&ballot); err != nil {
+		return fmt.Errorf("get ballot: %w", err)
+	}
+	if event.RequiresApproval || ballot {
+		return ErrSeatingConflict
+	}
+	if event.VenueID == nil {
+		return ErrNoSeatMap
+	}
+	if _, err = tx.Exec(ctx, `SELECT 1 FROM venues WHERE id = $1 FOR SHARE`, *event.VenueID); err != nil {
+		return fmt.Errorf("lock venue row: %w", err)
+	}
+	var seats int
+	if err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM venue_seats WHERE venue_id = $1`, *event.VenueID).Scan(&seats); err != nil {
+		return fmt.Errorf("count seats: %w", err)
+	}
+	if seats == 0 {
+		return ErrNoSeatMap
+	}
+	if seats < event.SellableCapacity() {
+		return fmt.Errorf("%w: %d seats, %d sellable", ErrNotEnoughSeats, seats, event.SellableCapacity())
+	}
+	return nil
+}
+
+// claimSeats checks the seats chosen for a booking at a locked event, one
+// per attendee, and returns them as the registrations store them: all nil
+// at events without reserved seating, which ignore any seat given.
+//
+// Each seat must be in the map of the event's venue, chosen once, and not
+// held by another registration or pending order for the event.  Two
+// bookings cannot both pass this check for the same seat, because both hold
+// the event row lock; the one_registration_per_seat and
+// one_pending_order_per_seat indexes back that up.  The chosen seat rows
+// are locked FOR SHARE, so SetSeatMap cannot remove them before the
+// booking commits.
+func claimSeats(ctx context.Context, tx pgx.Tx, event *model.Event, seatIDs []string) ([]*string, error) {
+	claimed := make([]*string, len(seatIDs))
+	if !event.ReservedSeating {
+		return claimed, nil
+	}
+	seen := make(map[string]bool, len(seatIDs))
+	for i, id := range seatIDs {
+		if id == "" {
+			return nil, ErrSeatRequired
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("%w: seat %s is chosen twice", ErrSeatTaken, id)
+		}
+		seen[id] = true
+		claimed[i] = &seatIDs[i]
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT id FROM venue_seats WHERE venue_id = $1 AND id = ANY($2) FOR SHARE`,
+		event.VenueID, seatIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("lock seats: %w", err)
+	}
+	found, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("lock seats: %w", err)
+	}
+	if len(found) < len(seatIDs) {
+		return nil, ErrSeatNotFound
+	}
+
+	rows, err = tx.Query(ctx,
+		`SELECT s.section, s.row_label, s.label
+		 FROM `+seatHolders+` r JOIN venue_seats s ON s.id = r.seat_id
+		 WHERE r.event_id = $1 AND r.seat_id = ANY($2)
+		 ORDER BY s.position`,
+		event.ID, seatIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("check taken seats: %w", err)
+	}
+	taken, err := seatNames(rows)
+	if err != nil {
+		return nil, fmt.Errorf("check taken seats: %w", err)
+	}
+	if len(taken) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrSeatTaken, strings.Join(taken, ", "))
+	}
+	return claimed, nil
+}
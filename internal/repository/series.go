@@ -0,0 +1,497 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrOccurrencesBooked is returned, wrapped in a *BookedOccurrencesError,
+// when an edit or delete would remove occurrences that have registrations,
+// orders that took, or may yet take, money, or refunds.
+var ErrOccurrencesBooked = errors.New("occurrences with registrations, orders or refunds cannot be removed")
+
+// BookedOccurrencesError lists the occurrences that blocked an edit or
+// delete.  Nothing has been changed when it is returned.
+type BookedOccurrencesError struct {
+	Occurrences []model.Event
+}
+
+func (e *BookedOccurrencesError) Error() string {
+	starts := make([]string, len(e.Occurrences))
+	for i, o := range e.Occurrences {
+		starts[i] = o.StartsAt.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s: %s", ErrOccurrencesBooked, strings.Join(starts, ", "))
+}
+
+func (e *BookedOccurrencesError) Unwrap() error { return ErrOccurrencesBooked }
+
+// SeriesRule is a new recurrence for the future occurrences of a series, in
+// canonical form, with the start times it yields from a given first start.
+type SeriesRule struct {
+	RRule    string
+	Timezone string
+	Expand   func(from time.Time) ([]time.Time, error)
+}
+
+// SeriesRepository handles persistence for event series.
+type SeriesRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSeriesRepository constructs a SeriesRepository.
+func NewSeriesRepository(db *pgxpool.Pool) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+// seriesColumns is the column list matching scanSeries.
+const seriesColumns = `id, name, description, capacity, venue_id, tags, rrule, timezone, starts_at, ends_at, created_at`
+
+// scanSeries scans a row selected with seriesColumns.
+func scanSeries(row pgx.Row) (*model.EventSeries, error) {
+	var s model.EventSeries
+	if err := row.Scan(&s.ID, &s.Name, &s.Description, &s.Capacity, &s.VenueID, &s.Tags,
+		&s.RRule, &s.Timezone, &s.StartsAt, &s.EndsAt, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// newOccurrence builds the event starting at start from the series template.
+func newOccurrence(series *model.EventSeries, start time.Time) *model.Event {
+	event := &model.Event{
+		ID:           uuid.New().String(),
+		Name:         series.Name,
+		Description:  series.Description,
+		Capacity:     series.Capacity,
+		StartsAt:     &start,
+		VenueID:      series.VenueID,
+		Tags:         series.Tags,
+		MaxGroupSize: model.DefaultMaxGroupSize,
+		SeriesID:     &series.ID,
+		CreatedAt:    time.Now().UTC(),
+		Visibility:   model.VisibilityPublic,
+		Currency:     model.DefaultCurrency,
+	}
+	if series.EndsAt != nil {
+		end := start.Add(series.EndsAt.Sub(series.StartsAt))
+		event.EndsAt = &end
+	}
+	return event
+}
+
+// Create stores a series and one event per start time in a single
+// transaction, and returns the series with its occurrences.
+func (r *SeriesRepository) Create(ctx context.Context, series *model.EventSeries, starts []time.Time) (*model.EventSeries, error) {
+	series.ID = uuid.New().String()
+	series.CreatedAt = time.Now().UTC()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err = checkVenueCapacity(ctx, tx, series.VenueID, series.Capacity); err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO event_series (id, name, description, capacity, venue_id, tags, rrule, timezone, starts_at, ends_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		series.ID, series.Name, series.Description, series.Capacity, series.VenueID, series.Tags,
+		series.RRule, series.Timezone, series.StartsAt, series.EndsAt, series.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert series: %w", err)
+	}
+	for _, start := range starts {
+		event := newOccurrence(series, start)
+		if err = insertEvent(ctx, tx, event); err != nil {
+			return nil, err
+		}
+		series.Occurrences = append(series.Occurrences, *event)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return series, nil
+}
+
+// Get returns a series with its occurrences in start order, or ErrNotFound.
+func (r *SeriesRepository) Get(ctx context.Context, id string) (*model.EventSeries, error) {
+	series, err := scanSeries(r.db.QueryRow(ctx,
+		`SELECT `+seriesColumns+` FROM event_series WHERE id = $1`, id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get series: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE series_id = $1 ORDER BY starts_at, id`, id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list occurrences: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+		series.Occurrences = append(series.Occurrences, *e)
+	}
+	return series, rows.Err()
+}
+
+// lockOccurrences locks the series row, which serializes edits to one series,
+// then the chosen occurrence and, with future, every occurrence starting at or
+// after it.  Event rows are locked in id order, like every multi-event lock
+// in this package, so concurrent edits and bookings cannot deadlock; they are
+// returned in start order.
+func lockOccurrences(ctx context.Context, tx pgx.Tx, seriesID, eventID string, future bool) (*model.EventSeries, []model.Event, error) {
+	series, err := scanSeries(tx.QueryRow(ctx,
+		`SELECT `+seriesColumns+` FROM event_series WHERE id = $1 FOR UPDATE`, seriesID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("lock series row: %w", err)
+	}
+
+	var from time.Time
+	err = tx.QueryRow(ctx,
+		`SELECT starts_at FROM events WHERE id = $1 AND series_id = $2`, eventID, seriesID,
+	).Scan(&from)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("get occurrence: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT `+eventColumns+` FROM events
+		 WHERE series_id = $1 AND (id = $2 OR ($3 AND starts_at >= $4))
+		 ORDER BY id
+		 FOR UPDATE`,
+		seriesID, eventID, future, from,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lock occurrences: %w", err)
+	}
+	defer rows.Close()
+	var events []model.Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scan event: %w", err)
+		}
+		events = append(events, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	slices.SortFunc(events, func(a, b model.Event) int { return a.StartsAt.Compare(*b.StartsAt) })
+	return series, events, nil
+}
+
+// deleteOccurrences deletes events unless any has registrations, orders
+// that are pending, paid or fulfilled, or refunds, in which case it returns
+// a *BookedOccurrencesError naming them and deletes nothing.  Orders cascade
+// with their event, and a pending order's payment may still succeed, so
+// deleting one would leave money taken with no order or seat to show for it.
+// A cancelled registration leaves no registration behind, but its refund
+// is still owed or is the record of money returned; refunds do not cascade.
+func deleteOccurrences(ctx context.Context, tx pgx.Tx, events []model.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT event_id FROM registrations WHERE event_id = ANY($1)
+		 UNION
+		 SELECT event_id FROM orders WHERE event_id = ANY($1) AND status IN ('pending', 'paid', 'fulfilled')
+		 UNION
+		 SELECT event_id FROM refunds WHERE event_id = ANY($1)`,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("check registrations: %w", err)
+	}
+	booked, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("check registrations: %w", err)
+	}
+	if len(booked) > 0 {
+		conflict := &BookedOccurrencesError{}
+		for _, e := range events {
+			if slices.Contains(booked, e.ID) {
+				conflict.Occurrences = append(conflict.Occurrences, e)
+			}
+		}
+		return conflict
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM events WHERE id = ANY($1)`, ids); err != nil {
+		return fmt.Errorf("delete occurrences: %w", err)
+	}
+	return nil
+}
+
+// UpdateFuture applies req to the chosen occurrence and every later one, and
+// to the series template that occurrences generated from then on are built
+// from.  StartsAt moves the chosen occurrence and shifts the later ones by
+// the same amount; EndsAt sets the duration of all of them.
+//
+// With a rule, the occurrences from the chosen one onwards are regenerated:
+// existing ones on a generated start (after the shift) are kept and updated,
+// new starts get new events, and the remaining ones are deleted — unless any
+// of those have registrations or orders, in which case nothing changes and a
+// *BookedOccurrencesError lists them.
+func (r *SeriesRepository) UpdateFuture(ctx context.Context, seriesID, eventID string, req model.UpdateEventRequest, rule *SeriesRule) (*model.EventSeries, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	series, future, err := lockOccurrences(ctx, tx, seriesID, eventID, true)
+	if err != nil {
+		return nil, err
+	}
+	var from time.Time
+	for _, e := range future {
+		if e.ID == eventID {
+			from = *e.StartsAt
+		}
+	}
+	newFrom := from
+	if req.StartsAt != nil {
+		newFrom = *req.StartsAt
+	}
+	shift := newFrom.Sub(from)
+	if req.EndsAt != nil && !req.EndsAt.After(newFrom) {
+		return nil, ErrInvalidSchedule
+	}
+
+	// ── Template ────────────────────────────────────────────────────────────
+	applyEventFields(&series.Name, &series.Description, &series.VenueID, &series.Tags, req)
+	if req.Capacity != nil {
+		series.Capacity = *req.Capacity
+	}
+	if req.StartsAt != nil || req.EndsAt != nil || rule != nil {
+		switch {
+		case req.EndsAt != nil:
+			end := *req.EndsAt
+			series.EndsAt = &end
+		case series.EndsAt != nil:
+			end := newFrom.Add(series.EndsAt.Sub(series.StartsAt))
+			series.EndsAt = &end
+		}
+		series.StartsAt = newFrom
+	}
+	if rule != nil {
+		series.RRule, series.Timezone = rule.RRule, rule.Timezone
+	}
+	if err = checkVenueCapacity(ctx, tx, series.VenueID, series.Capacity); err != nil {
+		return nil, err
+	}
+
+	// ── Regeneration ────────────────────────────────────────────────────────
+	kept := future
+	var created []time.Time
+	if rule != nil {
+		starts, err := rule.Expand(newFrom)
+		if err != nil {
+			return nil, err
+		}
+		wanted := make(map[int64]bool, len(starts))
+		for _, s := range starts {
+			wanted[s.UnixMicro()] = true
+		}
+		var removed []model.Event
+		kept = nil
+		for _, e := range future {
+			key := e.StartsAt.Add(shift).UnixMicro()
+			if wanted[key] {
+				delete(wanted, key)
+				kept = append(kept, e)
+			} else {
+				removed = append(removed, e)
+			}
+		}
+		for _, s := range starts {
+			if wanted[s.UnixMicro()] {
+				created = append(created, s)
+			}
+		}
+		if err = deleteOccurrences(ctx, tx, removed); err != nil {
+			return nil, err
+		}
+	}
+
+	// ── Existing occurrences ────────────────────────────────────────────────
+	for _, e := range kept {
+		applyEventFields(&e.Name, &e.Description, &e.VenueID, &e.Tags, req)
+		if err = applyCapacity(&e, req); err != nil {
+			return nil, fmt.Errorf("%w (occurrence %s)", err, e.StartsAt.UTC().Format(time.RFC3339))
+		}
+		if req.MaxGroupSize != nil {
+			e.MaxGroupSize = *req.MaxGroupSize
+		}
+		if req.RequiresApproval != nil {
+			e.RequiresApproval = *req.RequiresApproval
+		}
+		if req.Visibility != nil {
+			e.Visibility = *req.Visibility
+		}
+		if req.ReservedSeating != nil {
+			e.ReservedSeating = *req.ReservedSeating
+		}
+		if req.TransfersDisabled != nil {
+			e.TransfersDisabled = *req.TransfersDisabled
+		}
+		if req.PriceCents != nil {
+			e.PriceCents = *req.PriceCents
+		}
+		if req.Currency != nil {
+			e.Currency = *req.Currency
+		}
+		start := e.StartsAt.Add(shift)
+		e.StartsAt = &start
+		switch {
+		case req.EndsAt != nil:
+			end := start.Add(req.EndsAt.Sub(newFrom))
+			e.EndsAt = &end
+		case e.EndsAt != nil:
+			end := e.EndsAt.Add(shift)
+			e.EndsAt = &end
+		}
+		if req.VenueID != nil || req.Capacity != nil {
+			if err = checkVenueCapacity(ctx, tx, e.VenueID, e.Capacity); err != nil {
+				return nil, err
+			}
+		}
+		if err = checkSeating(ctx, tx, &e); err != nil {
+			return nil, fmt.Errorf("%w (occurrence %s)", err, e.StartsAt.UTC().Format(time.RFC3339))
+		}
+		if err = checkPricing(ctx, tx, &e); err != nil {
+			return nil, fmt.Errorf("%w (occurrence %s)", err, e.StartsAt.UTC().Format(time.RFC3339))
+		}
+
+		_, err = tx.Exec(ctx,
+			`UPDATE events
+			 SET name = $2, description = $3, capacity = $4, starts_at = $5, ends_at = $6, venue_id = $7,
+			     max_group_size = $8, requires_approval = $9, visibility = $10,
+			     oversell_percent = $11, oversell_seats = $12, reserved_seating = $13, transfers_disabled = $14,
+			     price_cents = $15, currency = $16
+			 WHERE id = $1`,
+			e.ID, e.Name, e.Description, e.Capacity, e.StartsAt, e.EndsAt, e.VenueID, e.MaxGroupSize,
+			e.RequiresApproval, e.Visibility, e.OversellPercent, e.OversellSeats, e.ReservedSeating,
+			e.TransfersDisabled, e.PriceCents, e.Currency,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("update occurrence: %w", err)
+		}
+		if req.Tags != nil {
+			if err = setEventTags(ctx, tx, e.ID, e.Tags); err != nil {
+				return nil, err
+			}
+		}
+		if req.Capacity != nil || req.OversellPercent != nil || req.OversellSeats != nil {
+			if err = promoteWaitlist(ctx, tx, &e); err != nil {
+				return nil, err
+			}
+		}
+		if err = enqueueWebhooks(ctx, tx, e.ID, model.WebhookEventUpdated, e); err != nil {
+			return nil, err
+		}
+	}
+
+	// ── New occurrences ─────────────────────────────────────────────────────
+	for _, start := range created {
+		if err = insertEvent(ctx, tx, newOccurrence(series, start)); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE event_series
+		 SET name = $2, description = $3, capacity = $4, venue_id = $5, tags = $6,
+		     rrule = $7, timezone = $8, starts_at = $9, ends_at = $10
+		 WHERE id = $1`,
+		series.ID, series.Name, series.Description, series.Capacity, series.VenueID, series.Tags,
+		series.RRule, series.Timezone, series.StartsAt, series.EndsAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update series: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return r.Get(ctx, seriesID)
+}
+
+// applyEventFields copies the descriptive fields of req onto an event or the
+// series template.
+func applyEventFields(name, description *string, venueID **string, tags *[]string, req model.UpdateEventRequest) {
+	if req.Name != nil {
+		*name = *req.Name
+	}
+	if req.Description != nil {
+		*description = *req.Description
+	}
+	if req.VenueID != nil {
+		*venueID = req.VenueID
+		if *req.VenueID == "" {
+			*venueID = nil
+		}
+	}
+	if req.Tags != nil {
+		*tags = *req.Tags
+	}
+}
+
+// DeleteOccurrences deletes the chosen occurrence of a series or, with
+// future, it and every later one.  If any of them has registrations or
+// orders (see deleteOccurrences) nothing is deleted and a
+// *BookedOccurrencesError lists them.
+func (r *SeriesRepository) DeleteOccurrences(ctx context.Context, seriesID, eventID string, future bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, events, err := lockOccurrences(ctx, tx, seriesID, eventID, future)
+	if err != nil {
+		return err
+	}
+	if err = deleteOccurrences(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
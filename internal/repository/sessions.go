@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrSessionFull is returned when a session has no remaining capacity.
+var ErrSessionFull = errors.New("session is fully booked")
+
+// ErrSessionAlreadyPicked is returned when an attendee picks the same session
+// twice.
+var ErrSessionAlreadyPicked = errors.New("session already picked")
+
+// ErrSessionConflict is returned, wrapped with the other session's title,
+// when a pick overlaps a session the attendee already picked.
+var ErrSessionConflict = errors.New("session overlaps another picked session")
+
+// ErrSessionOutsideEvent is returned when a session's slot does not fall
+// within its event's schedule.
+var ErrSessionOutsideEvent = errors.New("session must fall within the event's schedule")
+
+// SessionRepository handles persistence for event sessions and the sessions
+// attendees pick.
+type SessionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSessionRepository constructs a SessionRepository.
+func NewSessionRepository(db *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// sessionColumns is the column list matching scanSession.
+const sessionColumns = `id, event_id, title, starts_at, ends_at, capacity, booked_count, created_at`
+
+// scanSession scans a row selected with sessionColumns.
+func scanSession(row pgx.Row) (*model.Session, error) {
+	var s model.Session
+	if err := row.Scan(&s.ID, &s.EventID, &s.Title, &s.StartsAt, &s.EndsAt,
+		&s.Capacity, &s.BookedCount, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Create adds a session to an event.  The event is read under a share lock
+// so its schedule cannot change while the slot is checked against it.
+func (r *SessionRepository) Create(ctx context.Context, eventID string, req model.CreateSessionRequest) (*model.Session, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var startsAt, endsAt *time.Time
+	err = tx.QueryRow(ctx,
+		`SELECT starts_at, ends_at FROM events WHERE id = $1 FOR SHARE`, eventID,
+	).Scan(&startsAt, &endsAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	if (startsAt != nil && req.StartsAt.Before(*startsAt)) || (endsAt != nil && req.EndsAt.After(*endsAt)) {
+		return nil, ErrSessionOutsideEvent
+	}
+
+	session := &model.Session{
+		ID:        uuid.New().String(),
+		EventID:   eventID,
+		Title:     req.Title,
+		StartsAt:  *req.StartsAt,
+		EndsAt:    *req.EndsAt,
+		Capacity:  req.Capacity,
+		CreatedAt: time.Now().UTC(),
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO sessions (id, event_id, title, starts_at, ends_at, capacity, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		session.ID, session.EventID, session.Title, session.StartsAt, session.EndsAt,
+		session.Capacity, session.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert session: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return session, nil
+}
+
+// ListByEvent returns an event's sessions in start order.
+func (r *SessionRepository) ListByEvent(ctx context.Context, eventID string) ([]model.Session, error) {
+	return r.list(ctx,
+		`SELECT `+sessionColumns+` FROM sessions WHERE event_id = $1 ORDER BY starts_at, id`,
+		eventID,
+	)
+}
+
+// ListByRegistration returns the sessions an attendee picked, in start order.
+func (r *SessionRepository) ListByRegistration(ctx context.Context, eventID, registrationID string) ([]model.Session, error) {
+	return r.list(ctx,
+		`SELECT `+sessionColumns+` FROM sessions
+		 WHERE event_id = $1
+		   AND id IN (SELECT session_id FROM session_registrations WHERE registration_id = $2)
+		 ORDER BY starts_at, id`,
+		eventID, registrationID,
+	)
+}
+
+func (r *SessionRepository) list(ctx context.Context, query string, args ...any) ([]model.Session, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []model.Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan session: %w", err)
+		}
+		sessions = append(sessions, *s)
+	}
+	return sessions, rows.Err()
+}
+
+// Pick books a seat in a session for a registered attendee.
+//
+// It follows Book's pessimistic locking, one level down: the registration
+// row is locked first, which serializes one attendee's picks so two
+// overlapping sessions cannot both pass the overlap check, then the session
+// row is locked with FOR UPDATE so its booked_count cannot race.  Cancel
+// takes the same two locks in the same order.
+func (r *SessionRepository) Pick(ctx context.Context, eventID, registrationID, sessionID string) (*model.Session, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err = lockRegistration(ctx, tx, eventID, registrationID); err != nil {
+		return nil, err
+	}
+	session, err := scanSession(tx.QueryRow(ctx,
+		`SELECT `+sessionColumns+` FROM sessions WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		sessionID, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("lock session row: %w", err)
+	}
+
+	// The attendee's other picks, including this session if already picked,
+	// that overlap its slot.
+	var (
+		conflictID    string
+		conflictTitle string
+	)
+	err = tx.QueryRow(ctx,
+		`SELECT s.id, s.title
+		 FROM session_registrations sr
+		 JOIN sessions s ON s.id = sr.session_id
+		 WHERE sr.registration_id = $1
+		   AND s.starts_at < $3 AND s.ends_at > $2
+		 ORDER BY (s.id = $4) DESC, s.starts_at
+		 LIMIT 1`,
+		registrationID, session.StartsAt, session.EndsAt, session.ID,
+	).Scan(&conflictID, &conflictTitle)
+	switch {
+	case err == nil && conflictID == session.ID:
+		return nil, ErrSessionAlreadyPicked
+	case err == nil:
+		return nil, fmt.Errorf("%w: %q", ErrSessionConflict, conflictTitle)
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, fmt.Errorf("check overlapping sessions: %w", err)
+	}
+
+	if session.IsFull() {
+		return nil, ErrSessionFull
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE sessions SET booked_count = booked_count + 1 WHERE id = $1`, session.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("increment session booked_count: %w", err)
+	}
+	session.BookedCount++
+	_, err = tx.Exec(ctx,
+		`INSERT INTO session_registrations (session_id, registration_id) VALUES ($1, $2)`,
+		session.ID, registrationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert session pick: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return session, nil
+}
+
+// Drop removes an attendee's pick and releases the session seat, taking the
+// same locks as Pick.
+func (r *SessionRepository) Drop(ctx context.Context, eventID, registrationID, sessionID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err = lockRegistration(ctx, tx, eventID, registrationID); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx,
+		`DELETE FROM session_registrations WHERE registration_id = $1 AND session_id = $2`,
+		registrationID, sessionID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete session pick: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	_, err = tx.Exec(ctx,
+		`UPDATE sessions SET booked_count = booked_count - 1 WHERE id = $1`, sessionID,
+	)
+	if err != nil {
+		return fmt.Errorf("decrement session booked_count: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// lockRegistration locks a registration row of the event, or returns
+// ErrNotFound.
+func lockRegistration(ctx context.Context, tx pgx.Tx, eventID, registrationID string) error {
+	var id string
+	err := tx.QueryRow(ctx,
+		`SELECT id FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		registrationID, eventID,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("lock registration row: %w", err)
+	}
+	return nil
+}
+
+// releaseSessions drops every pick of a registration and releases the seats,
+// inside the caller's transaction.  The registration row is locked before
+// the session rows, the order Pick uses.
+func releaseSessions(ctx context.Context, tx pgx.Tx, eventID, registrationID string) error {
+	if err := lockRegistration(ctx, tx, eventID, registrationID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx,
+		`WITH released AS (
+		     DELETE FROM session_registrations WHERE registration_id = $1 RETURNING session_id
+		 )
+		 UPDATE sessions SET booked_count = booked_count - 1
+		 WHERE id IN (SELECT session_id FROM released)`,
+		registrationID,
+	)
+	if err != nil {
+		return fmt.Errorf("release sessions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TagRepository handles queries over event tags.  Tags are written together
+// with their event by EventRepository.Create and Update.
+type TagRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTagRepository constructs a TagRepository.
+func NewTagRepository(db *pgxpool.Pool) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// List returns every tag in use by public events with its event count, most
+// used first.
+func (r *TagRepository) List(ctx context.Context) ([]model.Tag, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT t.tag, COUNT(*)
+		 FROM event_tags t
+		 JOIN events e ON e.id = t.event_id AND e.visibility = 'public'
+		 GROUP BY t.tag
+		 ORDER BY COUNT(*) DESC, t.tag`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []model.Tag
+	for rows.Next() {
+		var t model.Tag
+		if err := rows.Scan(&t.Name, &t.EventCount); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// setEventTags replaces an event's tags inside the caller's transaction,
+// creating tags that do not exist yet.  tags must already be normalized.
+func setEventTags(ctx context.Context, tx pgx.Tx, eventID string, tags []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM event_tags WHERE event_id = $1`, eventID); err != nil {
+		return fmt.Errorf("clear event tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx,
+		`INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`,
+		tags,
+	)
+	if err != nil {
+		return fmt.Errorf("insert tags: %w", err)
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO event_tags (event_id, tag) SELECT $1, unnest($2::text[])`,
+		eventID, tags,
+	)
+	if err != nil {
+		return fmt.Errorf("insert event tags: %w", err)
+	}
+	return nil
+}
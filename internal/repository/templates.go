@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TemplateRepository handles persistence for per-event message templates.
+type TemplateRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTemplateRepository constructs a TemplateRepository.
+func NewTemplateRepository(db *pgxpool.Pool) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+// Get returns the event's override for kind, or ErrNotFound if the event
+// uses the default template.
+func (r *TemplateRepository) Get(ctx context.Context, eventID, kind string) (*model.MessageTemplate, error) {
+	t := model.MessageTemplate{EventID: eventID, Kind: kind}
+	err := r.db.QueryRow(ctx,
+		`SELECT subject, text_body, html_body, updated_at
+		 FROM message_templates
+		 WHERE event_id = $1 AND kind = $2`,
+		eventID, kind,
+	).Scan(&t.Subject, &t.TextBody, &t.HTMLBody, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get template: %w", err)
+	}
+	return &t, nil
+}
+
+// ListByEvent returns every override stored for an event.
+func (r *TemplateRepository) ListByEvent(ctx context.Context, eventID string) ([]model.MessageTemplate, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT kind, subject, text_body, html_body, updated_at
+		 FROM message_templates
+		 WHERE event_id = $1`,
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []model.MessageTemplate
+	for rows.Next() {
+		t := model.MessageTemplate{EventID: eventID}
+		if err := rows.Scan(&t.Kind, &t.Subject, &t.TextBody, &t.HTMLBody, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// Upsert creates or replaces the event's override for t.Kind.
+func (r *TemplateRepository) Upsert(ctx context.Context, t *model.MessageTemplate) error {
+	t.UpdatedAt = time.Now().UTC()
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO message_templates (event_id, kind, subject, text_body, html_body, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (event_id, kind) DO UPDATE
+		 SET subject = EXCLUDED.subject,
+		     text_body = EXCLUDED.text_body,
+		     html_body = EXCLUDED.html_body,
+		     updated_at = EXCLUDED.updated_at`,
+		t.EventID, t.Kind, t.Subject, t.TextBody, t.HTMLBody, t.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert template: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the event's override for kind, reverting to the default.
+func (r *TemplateRepository) Delete(ctx context.Context, eventID, kind string) error {
+	tag, err := r.db.Exec(ctx,
+		`DELETE FROM message_templates WHERE event_id = $1 AND kind = $2`,
+		eventID, kind,
+	)
+	if err != nil {
+		return fmt.Errorf("delete template: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
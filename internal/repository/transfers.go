@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrTransfersDisabled is returned when transferring a ticket of an event
+// whose organizer turned transfers off, or that approves each attendee.
+var ErrTransfersDisabled = errors.New("tickets for this event cannot be transferred")
+
+// ErrInvalidTicket is returned when a transfer's ticket code is not the
+// registration's current one.
+var ErrInvalidTicket = errors.New("ticket code is not valid for this registration")
+
+// ErrTicketUsed is returned when transferring a ticket that was already
+// used to check in.
+var ErrTicketUsed = errors.New("ticket was already used to check in")
+
+// Transfer moves a registration to a new holder (UserEmail, Name and
+// Answers of to) in place, authorized by its current ticket code.
+//
+// The event row is locked first, as in Book, so the duplicate check for the
+// new email is serialised with every booking; unique_registration backs it
+// up.  The registration keeps its id, seat, sessions and group, and gets a
+// new ticket code, so the old one stops working the moment this commits.
+// The transfer is recorded in ticket_transfers, and the new holder's
+// confirmation and the registration.transferred webhook are queued in the
+// same transaction.
+func (r *RegistrationRepository) Transfer(ctx context.Context, eventID, registrationID, ticketCode string, to model.Registration) (*model.Registration, *model.TicketTransfer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event, err := lockEvent(ctx, tx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if event.TransfersDisabled {
+		return nil, nil, ErrTransfersDisabled
+	}
+	if event.RequiresApproval {
+		return nil, nil, fmt.Errorf("%w: the organizer approves each attendee", ErrTransfersDisabled)
+	}
+
+	reg, err := scanRegistration(tx.QueryRow(ctx,
+		`SELECT `+registrationColumns+` FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		registrationID, eventID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("lock registration: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(reg.TicketCode), []byte(ticketCode)) != 1 {
+		return nil, nil, ErrInvalidTicket
+	}
+	if reg.CheckedInAt != nil {
+		return nil, nil, ErrTicketUsed
+	}
+
+	var registered bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM registrations WHERE event_id = $1 AND user_email = $2)`,
+		eventID, to.UserEmail,
+	).Scan(&registered)
+	if err != nil {
+		return nil, nil, fmt.Errorf("check duplicate: %w", err)
+	}
+	if registered {
+		return nil, nil, ErrAlreadyRegistered
+	}
+
+	transfer := &model.TicketTransfer{
+		ID:             uuid.New().String(),
+		EventID:        eventID,
+		RegistrationID: reg.ID,
+		FromEmail:      reg.UserEmail,
+		ToEmail:        to.UserEmail,
+		TransferredAt:  time.Now().UTC(),
+	}
+	if to.Answers == nil {
+		to.Answers = model.Answers{}
+	}
+	reg, err = scanRegistration(tx.QueryRow(ctx,
+		`UPDATE registrations
+		 SET user_email = $3, attendee_name = $4, answers = $5, ticket_code = DEFAULT
+		 WHERE id = $1 AND event_id = $2
+		 RETURNING `+registrationColumns,
+		reg.ID, eventID, to.UserEmail, to.Name, to.Answers,
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("transfer registration: %w", err)
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO ticket_transfers (id, event_id, registration_id, from_email, to_email, transferred_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		transfer.ID, transfer.EventID, transfer.RegistrationID, transfer.FromEmail, transfer.ToEmail,
+		transfer.TransferredAt,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("record transfer: %w", err)
+	}
+
+	err = enqueueOutbox(ctx, tx, model.NotificationConfirmation, reg.UserEmail, model.NotificationPayload{
+		EventID:        eventID,
+		EventName:      event.Name,
+		RegistrationID: reg.ID,
+		UserEmail:      reg.UserEmail,
+		TicketCode:     reg.TicketCode,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = enqueueWebhooks(ctx, tx, eventID, model.WebhookRegistrationTransferred, model.NewWebhookRegistration(reg)); err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return reg, transfer, nil
+}
+
+// Transfers returns an event's ticket transfers, oldest first, including
+// those of registrations cancelled since, or ErrNotFound.
+func (r *RegistrationRepository) Transfers(ctx context.Context, eventID string) ([]model.TicketTransfer, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM events WHERE id = $1)`, eventID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	rows, err := r.db.Query(ctx,
+		`SELECT id, event_id, registration_id, from_email, to_email, transferred_at
+		 FROM ticket_transfers
+		 WHERE event_id = $1
+		 ORDER BY transferred_at, id`,
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []model.TicketTransfer
+	for rows.Next() {
+		var t model.TicketTransfer
+		if err := rows.Scan(&t.ID, &t.EventID, &t.RegistrationID, &t.FromEmail, &t.ToEmail, &t.TransferredAt); err != nil {
+			return nil, fmt.Errorf("scan transfer: %w", err)
+		}
+		transfers = append(transfers, t)
+	}
+	return transfers, rows.Err()
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// VenueRepository handles persistence for venues.
+type VenueRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewVenueRepository constructs a VenueRepository.
+func NewVenueRepository(db *pgxpool.Pool) *VenueRepository {
+	return &VenueRepository{db: db}
+}
+
+const venueColumns = `id, name, address, latitude, longitude, max_capacity, created_at`
+
+func scanVenue(row pgx.Row) (*model.Venue, error) {
+	var v model.Venue
+	if err := row.Scan(&v.ID, &v.Name, &v.Address, &v.Latitude, &v.Longitude, &v.MaxCapacity, &v.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Create inserts a venue and returns it with a generated UUID.
+func (r *VenueRepository) Create(ctx context.Context, req model.CreateVenueRequest) (*model.Venue, error) {
+	v := &model.Venue{
+		ID:          uuid.New().String(),
+		Name:        req.Name,
+		Address:     req.Address,
+		Latitude:    *req.Latitude,
+		Longitude:   *req.Longitude,
+		MaxCapacity: req.MaxCapacity,
+		CreatedAt:   time.Now().UTC(),
+	}
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO venues (`+venueColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		v.ID, v.Name, v.Address, v.Latitude, v.Longitude, v.MaxCapacity, v.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert venue: %w", err)
+	}
+	return v, nil
+}
+
+// Get returns a single venue or ErrNotFound.
+func (r *VenueRepository) Get(ctx context.Context, id string) (*model.Venue, error) {
+	v, err := scanVenue(r.db.QueryRow(ctx, `SELECT `+venueColumns+` FROM venues WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get venue: %w", err)
+	}
+	return v, nil
+}
+
+// List returns all venues ordered by name.
+func (r *VenueRepository) List(ctx context.Context) ([]model.Venue, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+venueColumns+` FROM venues ORDER BY name, id`)
+	if err != nil {
+		return nil, fmt.Errorf("list venues: %w", err)
+	}
+	defer rows.Close()
+
+	var venues []model.Venue
+	for rows.Next() {
+		v, err := scanVenue(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan venue: %w", err)
+		}
+		venues = append(venues, *v)
+	}
+	return venues, rows.Err()
+}
+
+// checkVenueCapacity verifies, inside the caller's transaction, that an
+// event's capacity fits its venue (if any).
+func checkVenueCapacity(ctx context.Context, tx pgx.Tx, venueID *string, capacity int) error {
+	if venueID == nil {
+		return nil
+	}
+	var maxCapacity int
+	err := tx.QueryRow(ctx, `SELECT max_capacity FROM venues WHERE id = $1`, *venueID).Scan(&maxCapacity)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrVenueNotFound
+		}
+		return fmt.Errorf("get venue capacity: %w", err)
+	}
+	if capacity > maxCapacity {
+		return fmt.Errorf("%w (%d > %d)", ErrVenueCapacityExceeded, capacity, maxCapacity)
+	}
+	return nil
+}
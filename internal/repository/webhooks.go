@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WebhookRepository handles persistence for webhook endpoints and deliveries.
+type WebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWebhookRepository constructs a WebhookRepository.
+func NewWebhookRepository(db *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// enqueueWebhooks fans a notification out to every active endpoint that is
+// subscribed to eventType and scoped to eventID (or account-wide), inside the
+// caller's transaction.  Like the email outbox, the deliveries exist if and
+// only if the change they announce commits.
+func enqueueWebhooks(ctx context.Context, tx pgx.Tx, eventID, eventType string, data any) error {
+	payload, err := json.Marshal(model.WebhookPayload{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO webhook_deliveries (id, endpoint_id, event_type, payload)
+		 SELECT gen_random_uuid()::text, id, $2, $3
+		 FROM webhook_endpoints
+		 WHERE active
+		   AND (event_id IS NULL OR event_id = $1)
+		   AND $2 = ANY(event_types)`,
+		eventID, eventType, payload,
+	)
+	if err != nil {
+		return fmt.Errorf("enqueue webhooks: %w", err)
+	}
+	return nil
+}
+
+// ─── Endpoints ────────────────────────────────────────────────────────────────
+
+const endpointColumns = `id, event_id, url, secret, event_types, active, created_at`
+
+func scanEndpoint(row pgx.Row) (*model.WebhookEndpoint, error) {
+	var e model.WebhookEndpoint
+	if err := row.Scan(&e.ID, &e.EventID, &e.URL, &e.Secret, &e.EventTypes, &e.Active, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// CreateEndpoint inserts a new endpoint. The caller supplies the secret.
+func (r *WebhookRepository) CreateEndpoint(ctx context.Context, e *model.WebhookEndpoint) error {
+	e.ID = uuid.New().String()
+	e.Active = true
+	e.CreatedAt = time.Now().UTC()
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO webhook_endpoints (`+endpointColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		e.ID, e.EventID, e.URL, e.Secret, e.EventTypes, e.Active, e.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// GetEndpoint returns a single endpoint or ErrNotFound.
+func (r *WebhookRepository) GetEndpoint(ctx context.Context, id string) (*model.WebhookEndpoint, error) {
+	e, err := scanEndpoint(r.db.QueryRow(ctx,
+		`SELECT `+endpointColumns+` FROM webhook_endpoints WHERE id = $1`, id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get webhook endpoint: %w", err)
+	}
+	return e, nil
+}
+
+// ListEndpoints returns endpoints, newest first. A non-empty eventID limits
+// the result to that event's endpoints.
+func (r *WebhookRepository) ListEndpoints(ctx context.Context, eventID string) ([]model.WebhookEndpoint, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+endpointColumns+`
+		 FROM webhook_endpoints
+		 WHERE $1 = '' OR event_id = $1
+		 ORDER BY created_at DESC`,
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	var endpoints []model.WebhookEndpoint
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan webhook endpoint: %w", err)
+		}
+		endpoints = append(endpoints, *e)
+	}
+	return endpoints, rows.Err()
+}
+
+// DeleteEndpoint removes an endpoint and, by cascade, its delivery log.
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM webhook_endpoints WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete webhook endpoint: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ─── Deliveries ───────────────────────────────────────────────────────────────
+
+const deliveryColumns = `id, endpoint_id, event_type, payload, status, attempts,
+	last_status_code, last_error, next_attempt_at, created_at, delivered_at`
+
+func scanDelivery(row pgx.Row) (*model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	if err := row.Scan(&d.ID, &d.EndpointID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.LastStatusCode, &d.LastError, &d.NextAttemptAt, &d.CreatedAt, &d.DeliveredAt); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func collectDeliveries(rows pgx.Rows) ([]model.WebhookDelivery, error) {
+	defer rows.Close()
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, rows.Err()
+}
+
+// ListDeliveries returns the most recent deliveries for an endpoint.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, endpointID string, limit int) ([]model.WebhookDelivery, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+deliveryColumns+`
+		 FROM webhook_deliveries
+		 WHERE endpoint_id = $1
+		 ORDER BY created_at DESC
+		 LIMIT $2`,
+		endpointID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	return collectDeliveries(rows)
+}
+
+// Redeliver queues a fresh copy of an existing delivery (same endpoint and
+// payload, so the same occurrence ID) for immediate sending. The original row
+// is left untouched as part of the log.
+func (r *WebhookRepository) Redeliver(ctx context.Context, deliveryID string) (*model.WebhookDelivery, error) {
+	d, err := scanDelivery(r.db.QueryRow(ctx,
+		`INSERT INTO webhook_deliveries (id, endpoint_id, event_type, payload)
+		 SELECT $2, endpoint_id, event_type, payload
+		 FROM webhook_deliveries
+		 WHERE id = $1
+		 RETURNING `+deliveryColumns,
+		deliveryID, uuid.New().String(),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("redeliver webhook: %w", err)
+	}
+	return d, nil
+}
+
+// ClaimedDelivery is a due delivery together with where and how to send it.
+type ClaimedDelivery struct {
+	model.WebhookDelivery
+	URL    string
+	Secret string
+}
+
+// ClaimDeliveries leases up to limit due deliveries, using the same
+// FOR UPDATE SKIP LOCKED + lease scheme as OutboxRepository.Claim.
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]ClaimedDelivery, error) {
+	rows, err := r.db.Query(ctx,
+		`WITH claimed AS (
+		     UPDATE webhook_deliveries
+		     SET attempts = attempts + 1,
+		         next_attempt_at = NOW() + make_interval(secs => $2)
+		     WHERE id IN (
+		         SELECT id FROM webhook_deliveries
+		         WHERE status = 'pending' AND next_attempt_at <= NOW()
+		         ORDER BY next_attempt_at
+		         LIMIT $1
+		         FOR UPDATE SKIP LOCKED
+		     )
+		     RETURNING id, endpoint_id, event_type, payload, attempts
+		 )
+		 SELECT c.id, c.endpoint_id, c.event_type, c.payload, c.attempts, e.url, e.secret
+		 FROM claimed c
+		 JOIN webhook_endpoints e ON e.id = c.endpoint_id`,
+		limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var claimed []ClaimedDelivery
+	for rows.Next() {
+		var c ClaimedDelivery
+		if err := rows.Scan(&c.ID, &c.EndpointID, &c.EventType, &c.Payload, &c.Attempts, &c.URL, &c.Secret); err != nil {
+			return nil, fmt.Errorf("scan claimed delivery: %w", err)
+		}
+		claimed = append(claimed, c)
+	}
+	return claimed, rows.Err()
+}
+
+// MarkDelivered records a successful (2xx) delivery.
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id string, statusCode int) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE webhook_deliveries
+		 SET status = 'delivered', last_status_code = $2, last_error = '', delivered_at = NOW()
+		 WHERE id = $1`,
+		id, statusCode,
+	)
+	if err != nil {
+		return fmt.Errorf("mark webhook delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt. statusCode is nil when no HTTP
+// response was received. The delivery is retried at retryAt, or dead-lettered
+// when retryAt is nil.
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id string, statusCode *int, deliveryErr error, retryAt *time.Time) error {
+	var err error
+	if retryAt == nil {
+		_, err = r.db.Exec(ctx,
+			`UPDATE webhook_deliveries
+			 SET status = 'dead', last_status_code = $2, last_error = $3
+			 WHERE id = $1`,
+			id, statusCode, deliveryErr.Error(),
+		)
+	} else {
+		_, err = r.db.Exec(ctx,
+			`UPDATE webhook_deliveries
+			 SET last_status_code = $2, last_error = $3, next_attempt_at = $4
+			 WHERE id = $1`,
+			id, statusCode, deliveryErr.Error(), *retryAt,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("mark webhook failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// maxAllocationNameLength caps allocation names, which appear in URLs.
+const maxAllocationNameLength = 50
+
+// ListAllocations returns an event's allocations, oldest first.
+func (s *EventService) ListAllocations(ctx context.Context, eventID string) ([]model.Allocation, error) {
+	if _, err := s.events.GetByID(ctx, eventID); err != nil {
+		return nil, repository.ErrNotFound
+	}
+	return s.allocations.ListByEvent(ctx, eventID)
+}
+
+// SetAllocation creates or resizes a named allocation of seats.
+func (s *EventService) SetAllocation(ctx context.Context, eventID, name string, req model.SetAllocationRequest) (*model.Allocation, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxAllocationNameLength {
+		return nil, fmt.Errorf("allocation name must be 1-%d characters", maxAllocationNameLength)
+	}
+	if req.Seats < 1 {
+		return nil, fmt.Errorf("seats must be a positive integer")
+	}
+	alloc, err := s.allocations.Set(ctx, eventID, name, req.Seats)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrAllocationTooLarge) ||
+			errors.Is(err, repository.ErrAllocationBelowIssued) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("set allocation: %w", err)
+	}
+	return alloc, nil
+}
+
+// DeleteAllocation removes an allocation, releasing its unissued seats.
+func (s *EventService) DeleteAllocation(ctx context.Context, eventID, name string) error {
+	if err := s.allocations.Delete(ctx, eventID, strings.TrimSpace(name)); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("delete allocation: %w", err)
+	}
+	return nil
+}
+
+// IssueComp issues a complimentary registration against an allocation.
+// Answers are validated against the form as for Register, but the event's
+// eligibility rules and everything that gates public sale are skipped: the
+// organizer has chosen the guest.
+func (s *EventService) IssueComp(ctx context.Context, eventID, name string, req model.CompRequest) (*model.Registration, error) {
+	req.UserEmail = strings.TrimSpace(strings.ToLower(req.UserEmail))
+	if !isValidEmail(req.UserEmail) {
+		return nil, fmt.Errorf("user_email is not a valid email address")
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if utf8.RuneCountInString(req.Name) > maxNameLength {
+		return nil, fmt.Errorf("name cannot exceed %d characters", maxNameLength)
+	}
+	questions, err := s.questions(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := validateAnswers(questions, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+
+	reg, err := s.allocations.Comp(ctx, eventID, strings.TrimSpace(name), &model.Registration{
+		UserEmail: req.UserEmail,
+		Name:      req.Name,
+		SeatID:    normalizeSeatID(req.SeatID),
+		Answers:   answers,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrAlreadyRegistered) ||
+			errors.Is(err, repository.ErrAllocationExhausted) ||
+			errors.Is(err, repository.ErrEventFull) ||
+			isSeatError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("issue comp: %w", err)
+	}
+	return reg, nil
+}
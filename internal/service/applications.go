@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// apply files a pending application for Register.
+func (s *EventService) apply(ctx context.Context, eventID string, app *model.Application) (*model.Application, error) {
+	app, err := s.applications.Apply(ctx, eventID, app)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrAlreadyRegistered) ||
+			errors.Is(err, repository.ErrAlreadyApplied) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("apply for event: %w", err)
+	}
+	return app, nil
+}
+
+// ListApplications returns an event's applications, oldest first.  An
+// empty status lists them all.
+func (s *EventService) ListApplications(ctx context.Context, eventID, status string) ([]model.Application, error) {
+	if status != "" && !slices.Contains(model.ApplicationStatuses, status) {
+		return nil, fmt.Errorf("status must be one of %s", strings.Join(model.ApplicationStatuses, ", "))
+	}
+	return s.applications.ListByEvent(ctx, eventID, status)
+}
+
+// ApproveApplication books a seat for a pending application.  It fails with
+// ErrEventFull, leaving the application pending, if no seat is left.
+func (s *EventService) ApproveApplication(ctx context.Context, eventID, applicationID string) (*model.Application, error) {
+	app, err := s.applications.Approve(ctx, eventID, applicationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrApplicationDecided) ||
+			errors.Is(err, repository.ErrAlreadyRegistered) ||
+			errors.Is(err, repository.ErrEventFull) ||
+			errors.Is(err, repository.ErrSeatRequired) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("approve application: %w", err)
+	}
+	return app, nil
+}
+
+// RejectApplication declines a pending application and notifies the
+// applicant.
+func (s *EventService) RejectApplication(ctx context.Context, eventID, applicationID string) (*model.Application, error) {
+	app, err := s.applications.Reject(ctx, eventID, applicationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrApplicationDecided) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("reject application: %w", err)
+	}
+	return app, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// maxBallotWindow caps how long a ballot may accept entries.
+const maxBallotWindow = 90 * 24 * time.Hour
+
+// SetBallot puts an event into ballot mode, or moves the entry window of a
+// ballot that has not been drawn.  A new ballot gets a random seed; only
+// its SHA-256 is published until the draw.
+func (s *EventService) SetBallot(ctx context.Context, eventID string, req model.SetBallotRequest) (*model.Ballot, error) {
+	if req.OpensAt == nil || req.ClosesAt == nil {
+		return nil, fmt.Errorf("opens_at and closes_at are required")
+	}
+	if !req.ClosesAt.After(*req.OpensAt) {
+		return nil, fmt.Errorf("closes_at must be after opens_at")
+	}
+	if req.ClosesAt.Sub(*req.OpensAt) > maxBallotWindow {
+		return nil, fmt.Errorf("a ballot can accept entries for at most %d days", int(maxBallotWindow.Hours()/24))
+	}
+	if !req.ClosesAt.After(time.Now()) {
+		return nil, fmt.Errorf("closes_at must be in the future")
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("generate seed: %w", err)
+	}
+	seed := hex.EncodeToString(raw)
+	sum := sha256.Sum256([]byte(seed))
+
+	ballot, err := s.ballots.Set(ctx, eventID, req.OpensAt.UTC(), req.ClosesAt.UTC(), seed, hex.EncodeToString(sum[:]))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrBallotDrawn) ||
+			errors.Is(err, repository.ErrBallotUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("set ballot: %w", err)
+	}
+	return ballot, nil
+}
+
+// GetBallot returns an event's ballot and, once drawn, its seed and results.
+func (s *EventService) GetBallot(ctx context.Context, eventID string) (*model.Ballot, error) {
+	return s.ballots.Get(ctx, eventID)
+}
+
+// DrawBallot runs an event's draw now that its window has closed.
+func (s *EventService) DrawBallot(ctx context.Context, eventID string) (*model.Ballot, error) {
+	ballot, err := s.ballots.Draw(ctx, eventID, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrBallotDrawn) ||
+			errors.Is(err, repository.ErrBallotNotClosed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("draw ballot: %w", err)
+	}
+	return ballot, nil
+}
+
+// DrawDueBallots draws every ballot whose window has closed.  It is run by
+// the job runner; a ballot drawn meanwhile by its organizer is skipped.
+func (s *EventService) DrawDueBallots(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	ids, err := s.ballots.Due(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	drawn := 0
+	for _, id := range ids {
+		_, err := s.ballots.Draw(ctx, id, now)
+		if errors.Is(err, repository.ErrBallotDrawn) {
+			continue
+		}
+		if err != nil {
+			return drawn, fmt.Errorf("draw ballot for event %s: %w", id, err)
+		}
+		drawn++
+	}
+	return drawn, nil
+}
+
+// enterBallot adds an entry for Register.
+func (s *EventService) enterBallot(ctx context.Context, eventID string, entry *model.BallotEntry) (*model.BallotEntry, error) {
+	entry, err := s.ballots.Enter(ctx, eventID, entry, time.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrBallotNotOpen) ||
+			errors.Is(err, repository.ErrAlreadyEntered) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("enter ballot: %w", err)
+	}
+	return entry, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/notify"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// BroadcastSendJob is the job kind that delivers one batch of a broadcast.
+const BroadcastSendJob = "broadcast.send"
+
+// Delivery limits: how often a single address is tried before it is marked
+// failed, and how often one batch job is retried by the runner.
+const (
+	maxRecipientAttempts = 3
+	broadcastJobAttempts = 5
+)
+
+// broadcastSendLease is how long a batch holds each of its recipients: twice
+// the SMTP timeout, so a batch of slow sends still finishes inside its lease.
+const broadcastSendLease = time.Minute
+
+// broadcastAudiences lists the audiences an organizer may target.
+var broadcastAudiences = []string{
+	model.AudienceAll, model.AudienceConfirmed, model.AudienceCheckedIn, model.AudienceWaitlisted,
+}
+
+// BroadcastConfig throttles broadcast delivery and builds unsubscribe links.
+type BroadcastConfig struct {
+	BatchSize     int           // recipients sent per batch
+	BatchInterval time.Duration // pause between batches
+	BaseURL       string        // public URL prefix for unsubscribe links
+}
+
+// BroadcastService composes organizer messages and delivers them in
+// throttled batches through the job queue.
+type BroadcastService struct {
+	events     *repository.EventRepository
+	broadcasts *repository.BroadcastRepository
+	jobs       *repository.JobRepository
+	notifier   notify.Notifier
+	cfg        BroadcastConfig
+}
+
+// NewBroadcastService constructs a BroadcastService with its dependencies.
+func NewBroadcastService(
+	events *repository.EventRepository,
+	broadcasts *repository.BroadcastRepository,
+	jobs *repository.JobRepository,
+	notifier notify.Notifier,
+	cfg BroadcastConfig,
+) *BroadcastService {
+	cfg.BatchSize = max(cfg.BatchSize, 1)
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &BroadcastService{events: events, broadcasts: broadcasts, jobs: jobs, notifier: notifier, cfg: cfg}
+}
+
+// SendBroadcast validates a message, freezes its recipient list and queues
+// delivery.  It returns as soon as the message is stored; delivery progress
+// is visible through the message counts and recipient list.
+func (s *BroadcastService) SendBroadcast(ctx context.Context, eventID string, req model.SendBroadcastRequest) (*model.BroadcastMessage, error) {
+	req.Subject = strings.TrimSpace(req.Subject)
+	if req.Subject == "" {
+		return nil, fmt.Errorf("subject is required")
+	}
+	if len(req.Subject) > 200 {
+		return nil, fmt.Errorf("subject cannot exceed 200 characters")
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return nil, fmt.Errorf("body is required")
+	}
+	if req.Audience == "" {
+		req.Audience = model.AudienceAll
+	}
+	if !slices.Contains(broadcastAudiences, req.Audience) {
+		return nil, fmt.Errorf("unknown audience %q (want one of %v)", req.Audience, broadcastAudiences)
+	}
+	if _, err := s.events.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	msg := &model.BroadcastMessage{
+		EventID:  eventID,
+		Subject:  req.Subject,
+		Body:     req.Body,
+		Audience: req.Audience,
+	}
+	if err := s.broadcasts.Create(ctx, msg, BroadcastSendJob); err != nil {
+		return nil, err
+	}
+	return s.broadcasts.Get(ctx, msg.ID)
+}
+
+// ListMessages returns an event's broadcasts, newest first.
+func (s *BroadcastService) ListMessages(ctx context.Context, eventID string) ([]model.BroadcastMessage, error) {
+	if _, err := s.events.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return s.broadcasts.ListByEvent(ctx, eventID)
+}
+
+// ListRecipients returns the per-recipient delivery status of a broadcast.
+func (s *BroadcastService) ListRecipients(ctx context.Context, eventID, messageID string) ([]model.BroadcastRecipient, error) {
+	msg, err := s.broadcasts.Get(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg.EventID != eventID {
+		return nil, repository.ErrNotFound
+	}
+	return s.broadcasts.ListRecipients(ctx, messageID)
+}
+
+// LookupUnsubscribe returns the event name and address behind an unsubscribe
+// token, for the confirmation page.
+func (s *BroadcastService) LookupUnsubscribe(ctx context.Context, token string) (eventName, email string, err error) {
+	return s.broadcasts.LookupUnsubscribe(ctx, token)
+}
+
+// Unsubscribe opts the token's address out of the event's broadcasts,
+// including batches of messages that are still being sent.
+func (s *BroadcastService) Unsubscribe(ctx context.Context, token string) error {
+	return s.broadcasts.Unsubscribe(ctx, token)
+}
+
+// HandleSendJob delivers the next batch of a broadcast.  While recipients
+// remain it queues itself again after the batch interval, which is what
+// throttles delivery; the last batch marks the message completed.
+//
+// Each recipient's outcome is recorded as soon as it is known, so a retried
+// job never mails an address that was already sent to.  The next job's
+// unique key is derived from the message and batch number, so a retried job
+// does not start a second chain of batches.
+func (s *BroadcastService) HandleSendJob(ctx context.Context, payload json.RawMessage) error {
+	var p struct {
+		MessageID string `json:"message_id"`
+		Batch     int    `json:"batch"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+
+	batch, err := s.broadcasts.NextBatch(ctx, p.MessageID, s.cfg.BatchSize, time.Duration(s.cfg.BatchSize)*broadcastSendLease)
+	if err != nil {
+		return err
+	}
+	if len(batch) == 0 {
+		// Recipients still leased by a job that died mid-batch keep the
+		// message sending; check again once their lease may have run out.
+		done, err := s.broadcasts.Complete(ctx, p.MessageID)
+		if err != nil || done {
+			return err
+		}
+		return s.enqueueNextBatch(ctx, p.MessageID, p.Batch+1, broadcastSendLease)
+	}
+
+	msg, err := s.broadcasts.Get(ctx, p.MessageID)
+	if err != nil {
+		return err
+	}
+	for _, rc := range batch {
+		sendErr := s.notifier.Notify(ctx, notify.Message{
+			To:      rc.Email,
+			Subject: msg.Subject,
+			Body:    msg.Body + s.unsubscribeFooter(rc.UnsubscribeToken),
+		})
+		if sendErr != nil {
+			log.Printf("broadcast %s: %s (attempt %d/%d): %v",
+				p.MessageID, rc.Email, rc.Attempts+1, maxRecipientAttempts, sendErr)
+			err = s.broadcasts.MarkRecipientFailed(ctx, p.MessageID, rc.Email, sendErr, rc.Attempts+1 >= maxRecipientAttempts)
+		} else {
+			err = s.broadcasts.MarkRecipientSent(ctx, p.MessageID, rc.Email)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.enqueueNextBatch(ctx, p.MessageID, p.Batch+1, s.cfg.BatchInterval)
+}
+
+// enqueueNextBatch queues batch number seq of a broadcast after delay.
+// Queueing the same batch twice is a no-op.
+func (s *BroadcastService) enqueueNextBatch(ctx context.Context, messageID string, seq int, delay time.Duration) error {
+	payload := map[string]any{"message_id": messageID, "batch": seq}
+	key := fmt.Sprintf("broadcast:%s:%d", messageID, seq)
+	_, err := s.jobs.Enqueue(ctx, BroadcastSendJob, payload, time.Now().UTC().Add(delay), broadcastJobAttempts, key)
+	return err
+}
+
+// unsubscribeFooter is appended to every broadcast body.
+func (s *BroadcastService) unsubscribeFooter(token string) string {
+	return "\n\n--\nYou received this because you registered for this event.\n" +
+		"Unsubscribe from further messages: " + s.cfg.BaseURL + "/unsubscribe/" + token + "\n"
+}
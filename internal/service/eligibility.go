@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// Limits on the size of an event's eligibility rules.
+const (
+	maxRuleDomains      = 100
+	maxRuleLimits       = 10
+	maxRegistrationsCap = 1000
+)
+
+// GetEligibility returns an event's eligibility rules.
+func (s *EventService) GetEligibility(ctx context.Context, eventID string) (*model.EligibilityRules, error) {
+	rules, err := s.events.Eligibility(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get eligibility rules: %w", err)
+	}
+	return rules, nil
+}
+
+// SetEligibility validates and replaces an event's eligibility rules.
+// Required answers must name questions the event's form has.
+func (s *EventService) SetEligibility(ctx context.Context, eventID string, rules model.EligibilityRules) (*model.EligibilityRules, error) {
+	event, err := s.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.questions(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEligibility(&rules, event, questions); err != nil {
+		return nil, err
+	}
+	if err := s.events.SetEligibility(ctx, eventID, rules); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("set eligibility rules: %w", err)
+	}
+	return &rules, nil
+}
+
+// validateEligibility checks rules for event and normalizes them in place:
+// domains lower-cased without a leading "@", tags normalized like event
+// tags.
+func validateEligibility(rules *model.EligibilityRules, event *model.Event, questions []model.Question) error {
+	var err error
+	if rules.AllowedDomains, err = normalizeDomains("allowed_domains", rules.AllowedDomains); err != nil {
+		return err
+	}
+	if rules.BlockedDomains, err = normalizeDomains("blocked_domains", rules.BlockedDomains); err != nil {
+		return err
+	}
+
+	if len(rules.Limits) > maxRuleLimits {
+		return fmt.Errorf("at most %d limits are allowed", maxRuleLimits)
+	}
+	for i := range rules.Limits {
+		l := &rules.Limits[i]
+		if l.Max < 1 || l.Max > maxRegistrationsCap {
+			return fmt.Errorf("limit %d: max must be between 1 and %d", i+1, maxRegistrationsCap)
+		}
+		switch l.Scope {
+		case model.LimitScopeSeries:
+			if event.SeriesID == nil {
+				return fmt.Errorf("limit %d: event is not part of a series", i+1)
+			}
+			l.Tag = ""
+		case model.LimitScopeTag:
+			tags, err := normalizeTags([]string{l.Tag})
+			if err != nil {
+				return fmt.Errorf("limit %d: %w", i+1, err)
+			}
+			l.Tag = tags[0]
+		default:
+			return fmt.Errorf("limit %d: scope must be one of %v", i+1, model.LimitScopes)
+		}
+	}
+
+	for i := range rules.RequiredAnswers {
+		ra := &rules.RequiredAnswers[i]
+		if !slices.ContainsFunc(questions, func(q model.Question) bool { return q.ID == ra.QuestionID }) {
+			return fmt.Errorf("required answer %d: unknown question %q", i+1, ra.QuestionID)
+		}
+		for j, v := range ra.Accepted {
+			ra.Accepted[j] = strings.TrimSpace(v)
+		}
+	}
+	return nil
+}
+
+// normalizeDomains lower-cases and de-duplicates a rule's email domains.
+func normalizeDomains(field string, domains []string) ([]string, error) {
+	if len(domains) > maxRuleDomains {
+		return nil, fmt.Errorf("%s can list at most %d domains", field, maxRuleDomains)
+	}
+	out := make([]string, 0, len(domains))
+	for _, d := range domains {
+		d = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), "@")
+		if !strings.Contains(d, ".") || strings.ContainsAny(d, " @") {
+			return nil, fmt.Errorf("%s: %q is not a valid domain", field, d)
+		}
+		out = append(out, d)
+	}
+	slices.Sort(out)
+	return slices.Compact(out), nil
+}
+
+// checkEligibility evaluates an event's rules for one attendee before
+// anything is booked, and returns an *repository.EligibilityError listing
+// every rule failed.  bundle holds the other events booked in the same
+// request; those in a limit's scope count towards it.
+//
+// The limits are counted outside any lock: two registrations for different
+// events of a series racing each other can both pass.  They guard against
+// ordinary over-registration, not a determined attacker.
+func (s *EventService) checkEligibility(ctx context.Context, eventID, email string, answers model.Answers, bundle []string) error {
+	rules, err := s.GetEligibility(ctx, eventID)
+	if err != nil || rules.IsZero() {
+		return err
+	}
+
+	var reasons []model.EligibilityReason
+	domain := email[strings.LastIndex(email, "@")+1:]
+	if len(rules.AllowedDomains) > 0 && !matchesDomain(domain, rules.AllowedDomains) {
+		reasons = append(reasons, model.EligibilityReason{
+			Rule:    model.EligibilityDomainNotAllowed,
+			Message: fmt.Sprintf("only %s email addresses may register", strings.Join(rules.AllowedDomains, ", ")),
+		})
+	}
+	if matchesDomain(domain, rules.BlockedDomains) {
+		reasons = append(reasons, model.EligibilityReason{
+			Rule:    model.EligibilityDomainBlocked,
+			Message: fmt.Sprintf("%s email addresses may not register", domain),
+		})
+	}
+
+	if len(rules.Limits) > 0 {
+		event, err := s.GetEvent(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		others := make([]*model.Event, 0, len(bundle))
+		for _, id := range bundle {
+			if id == eventID {
+				continue
+			}
+			if other, err := s.GetEvent(ctx, id); err == nil {
+				others = append(others, other)
+			}
+		}
+		for _, l := range rules.Limits {
+			key := l.Tag
+			if l.Scope == model.LimitScopeSeries {
+				if event.SeriesID == nil {
+					continue // detached from its series since the rule was set
+				}
+				key = *event.SeriesID
+			}
+			n, err := s.registrations.CountInScope(ctx, email, l.Scope, key)
+			if err != nil {
+				return err
+			}
+			for _, o := range others {
+				if inScope(o, l.Scope, key) {
+					n++
+				}
+			}
+			if n+1 > l.Max {
+				what := "events in this series"
+				if l.Scope == model.LimitScopeTag {
+					what = fmt.Sprintf("%q events", l.Tag)
+				}
+				reasons = append(reasons, model.EligibilityReason{
+					Rule:    model.EligibilityLimitReached,
+					Message: fmt.Sprintf("one email may register for at most %d %s", l.Max, what),
+				})
+			}
+		}
+	}
+
+	for _, ra := range rules.RequiredAnswers {
+		given := answerValues(answers[ra.QuestionID])
+		switch {
+		case len(given) == 0:
+			reasons = append(reasons, model.EligibilityReason{
+				Rule:    model.EligibilityAnswerMissing,
+				Message: fmt.Sprintf("question %q must be answered", ra.QuestionID),
+			})
+		case len(ra.Accepted) > 0 && !slices.ContainsFunc(given, func(v string) bool { return slices.Contains(ra.Accepted, v) }):
+			reasons = append(reasons, model.EligibilityReason{
+				Rule:    model.EligibilityAnswerNotAccepted,
+				Message: fmt.Sprintf("the answer to %q does not meet the event's requirements", ra.QuestionID),
+			})
+		}
+	}
+
+	if len(reasons) > 0 {
+		return &repository.EligibilityError{Reasons: reasons}
+	}
+	return nil
+}
+
+// matchesDomain reports whether domain is one of domains or a subdomain of
+// one.
+func matchesDomain(domain string, domains []string) bool {
+	return slices.ContainsFunc(domains, func(d string) bool {
+		return domain == d || strings.HasSuffix(domain, "."+d)
+	})
+}
+
+// inScope reports whether event is in the scope of a limit keyed by key.
+func inScope(event *model.Event, scope, key string) bool {
+	if scope == model.LimitScopeSeries {
+		return event.SeriesID != nil && *event.SeriesID == key
+	}
+	return slices.Contains(event.Tags, key)
+}
+
+// answerValues flattens a normalized answer to the strings a required
+// answer is compared with.
+func answerValues(answer any) []string {
+	switch v := answer.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	}
+	return nil
+}
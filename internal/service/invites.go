@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// Invite code generation limits.
+const (
+	maxInvitesPerRequest = 1000
+	maxInviteUses        = 10_000
+	maxInviteLabelLength = 100
+	inviteCodeLength     = 10
+)
+
+// inviteAlphabet leaves out 0/O and 1/I so codes survive being read aloud
+// or copied from print.  Its 32 letters let a random byte pick one without
+// bias.
+const inviteAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// CreateInvites generates req.Count random invite codes for an event, each
+// usable req.MaxUses times.
+func (s *EventService) CreateInvites(ctx context.Context, eventID string, req model.CreateInvitesRequest) ([]model.InviteCode, error) {
+	if req.Count < 1 || req.Count > maxInvitesPerRequest {
+		return nil, fmt.Errorf("count must be between 1 and %d", maxInvitesPerRequest)
+	}
+	if req.MaxUses == 0 {
+		req.MaxUses = 1
+	}
+	if req.MaxUses < 1 || req.MaxUses > maxInviteUses {
+		return nil, fmt.Errorf("max_uses must be between 1 and %d", maxInviteUses)
+	}
+	req.Label = strings.TrimSpace(req.Label)
+	if utf8.RuneCountInString(req.Label) > maxInviteLabelLength {
+		return nil, fmt.Errorf("label cannot exceed %d characters", maxInviteLabelLength)
+	}
+
+	codes := make([]string, req.Count)
+	raw := make([]byte, inviteCodeLength)
+	for i := range codes {
+		if _, err := rand.Read(raw); err != nil {
+			return nil, fmt.Errorf("generate invite code: %w", err)
+		}
+		for j, b := range raw {
+			raw[j] = inviteAlphabet[b%byte(len(inviteAlphabet))]
+		}
+		codes[i] = string(raw)
+	}
+
+	invites, err := s.invites.Create(ctx, eventID, codes, req.MaxUses, req.Label)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("create invite codes: %w", err)
+	}
+	return invites, nil
+}
+
+// ListInvites returns an event's invite codes, oldest first.
+func (s *EventService) ListInvites(ctx context.Context, eventID string) ([]model.InviteCode, error) {
+	if _, err := s.events.GetByID(ctx, eventID); err != nil {
+		return nil, repository.ErrNotFound
+	}
+	return s.invites.ListByEvent(ctx, eventID)
+}
+
+// RevokeInvite stops an invite code from admitting further registrations.
+func (s *EventService) RevokeInvite(ctx context.Context, eventID, code string) (*model.InviteCode, error) {
+	invite, err := s.invites.Revoke(ctx, eventID, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("revoke invite code: %w", err)
+	}
+	return invite, nil
+}
+
+// normalizeInviteCode trims and upper-cases a code as typed by an attendee;
+// an empty code becomes nil.
+func normalizeInviteCode(code string) *string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil
+	}
+	return &code
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// jobListLimit caps how many jobs the status endpoint returns.
+const jobListLimit = 200
+
+// JobService exposes background job status and manual retries.
+type JobService struct {
+	jobs *repository.JobRepository
+}
+
+// NewJobService constructs a JobService.
+func NewJobService(jobs *repository.JobRepository) *JobService {
+	return &JobService{jobs: jobs}
+}
+
+// ListJobs returns recent jobs, optionally filtered by status.
+func (s *JobService) ListJobs(ctx context.Context, status string) ([]model.Job, error) {
+	if status != "" && !slices.Contains(model.JobStatuses, status) {
+		return nil, fmt.Errorf("unknown status %q (want one of %v)", status, model.JobStatuses)
+	}
+	return s.jobs.List(ctx, status, jobListLimit)
+}
+
+// GetJob returns a single job.
+func (s *JobService) GetJob(ctx context.Context, id string) (*model.Job, error) {
+	return s.jobs.Get(ctx, id)
+}
+
+// RetryJob re-queues a failed job.
+func (s *JobService) RetryJob(ctx context.Context, id string) (*model.Job, error) {
+	job, err := s.jobs.Retry(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrJobNotRetryable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("retry job: %w", err)
+	}
+	return job, nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/payment"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// PaymentConfig tunes how paid tickets are sold.
+type PaymentConfig struct {
+	Provider payment.Provider
+	Hold     time.Duration // how long an order holds its seat awaiting payment
+}
+
+// placeOrder places an order for Register at a paid event and starts its
+// payment.  The provider is called after the order commits, so no lock is
+// held while it answers; if it fails, the order fails and its seat is
+// released at once rather than when the hold runs out.
+func (s *EventService) placeOrder(ctx context.Context, eventID string, order *model.Order, accessCode string) (*model.Order, error) {
+	order.Provider = s.payments.Provider.Name()
+	order, err := s.orders.Create(ctx, eventID, order, accessCode, s.payments.Hold)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrEventFull) ||
+			errors.Is(err, repository.ErrAlreadyRegistered) ||
+			errors.Is(err, repository.ErrOrderPending) ||
+			errors.Is(err, repository.ErrInviteRequired) ||
+			errors.Is(err, repository.ErrInvalidInvite) ||
+			errors.Is(err, repository.ErrNotOnSale) ||
+			errors.Is(err, repository.ErrAccessCodeRequired) ||
+			isSeatError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("place order: %w", err)
+	}
+
+	p, err := s.payments.Provider.CreatePayment(ctx, payment.Request{
+		OrderID:     order.ID,
+		AmountCents: order.AmountCents,
+		Currency:    order.Currency,
+		Email:       order.UserEmail,
+		Description: "Ticket order " + order.ID,
+	})
+	if err != nil {
+		if _, failErr := s.orders.Fail(ctx, order.ID, "payment could not be started"); failErr != nil {
+			return nil, fmt.Errorf("start payment: %w (and fail order: %v)", err, failErr)
+		}
+		return nil, fmt.Errorf("start payment: %w", err)
+	}
+	order, err = s.orders.SetPayment(ctx, order.ID, p.ID, p.CheckoutURL)
+	if err != nil {
+		return nil, fmt.Errorf("save payment: %w", err)
+	}
+	return order, nil
+}
+
+// HandlePaymentCallback verifies a callback from the payment provider and
+// settles the order it reports on.  Redelivered callbacks change nothing.
+func (s *EventService) HandlePaymentCallback(ctx context.Context, body []byte, header http.Header) (*model.Order, error) {
+	cb, err := s.payments.Provider.ParseCallback(body, header)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.orders.ApplyPayment(ctx, s.payments.Provider.Name(), cb.ID, cb.PaymentID,
+		cb.Status == payment.StatusSucceeded, cb.Reason)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("apply payment: %w", err)
+	}
+	return order, nil
+}
+
+// SimulatePayment ends a fake payment with status through the same path a
+// real callback takes.  It is only available with the fake provider.
+func (s *EventService) SimulatePayment(ctx context.Context, paymentID, status string) (*model.Order, error) {
+	fake, ok := s.payments.Provider.(*payment.Fake)
+	if !ok {
+		return nil, payment.ErrSimulationUnavailable
+	}
+	body, header, err := fake.Simulate(paymentID, status)
+	if err != nil {
+		return nil, err
+	}
+	return s.HandlePaymentCallback(ctx, body, header)
+}
+
+// GetOrder returns an order.
+func (s *EventService) GetOrder(ctx context.Context, id string) (*model.Order, error) {
+	order, err := s.orders.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get order: %w", err)
+	}
+	return order, nil
+}
+
+// ListOrders returns an event's orders, oldest first, optionally only those
+// in status.
+func (s *EventService) ListOrders(ctx context.Context, eventID, status string) ([]model.Order, error) {
+	if status != "" && !slices.Contains(model.OrderStatuses, status) {
+		return nil, fmt.Errorf("status must be one of %v", model.OrderStatuses)
+	}
+	orders, err := s.orders.ListByEvent(ctx, eventID, status)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("list orders: %w", err)
+	}
+	return orders, nil
+}
+
+// ExpireOrders expires every pending order whose hold has run out,
+// releasing its seat.  It is run by the job runner; an order settled
+// meanwhile is skipped.
+func (s *EventService) ExpireOrders(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	ids, err := s.orders.Due(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	for _, id := range ids {
+		order, err := s.orders.Expire(ctx, id, now)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return expired, fmt.Errorf("expire order %s: %w", id, err)
+		}
+		if order.Status == model.OrderExpired {
+			expired++
+		}
+	}
+	return expired, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// Limits on an event's sale phases.
+const (
+	maxSalePhases       = 10
+	maxPhaseNameLength  = 50
+	maxAccessCodeLength = 50
+)
+
+// GetSalePhases returns an event's sale phases in start order with the
+// seats each has sold.
+func (s *EventService) GetSalePhases(ctx context.Context, eventID string) ([]model.SalePhase, error) {
+	phases, err := s.events.SalePhases(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get sale phases: %w", err)
+	}
+	return phases, nil
+}
+
+// SetSalePhases validates and replaces an event's sale phases.  An empty
+// list puts every seat on sale again, at any time.
+func (s *EventService) SetSalePhases(ctx context.Context, eventID string, phases []model.SalePhase) ([]model.SalePhase, error) {
+	if len(phases) > maxSalePhases {
+		return nil, fmt.Errorf("an event can have at most %d sale phases", maxSalePhases)
+	}
+	names := make([]string, 0, len(phases))
+	for i := range phases {
+		p := &phases[i]
+		p.Name = strings.TrimSpace(p.Name)
+		if p.Name == "" || utf8.RuneCountInString(p.Name) > maxPhaseNameLength {
+			return nil, fmt.Errorf("phase %d: name must be 1-%d characters", i+1, maxPhaseNameLength)
+		}
+		if slices.Contains(names, p.Name) {
+			return nil, fmt.Errorf("phase %q is listed twice", p.Name)
+		}
+		names = append(names, p.Name)
+		if p.StartsAt.IsZero() {
+			return nil, fmt.Errorf("phase %q: starts_at is required", p.Name)
+		}
+		p.StartsAt = p.StartsAt.UTC()
+		if p.EndsAt != nil {
+			*p.EndsAt = p.EndsAt.UTC()
+			if !p.EndsAt.After(p.StartsAt) {
+				return nil, fmt.Errorf("phase %q: ends_at must be after starts_at", p.Name)
+			}
+		}
+		p.Code = strings.ToUpper(strings.TrimSpace(p.Code))
+		if utf8.RuneCountInString(p.Code) > maxAccessCodeLength {
+			return nil, fmt.Errorf("phase %q: code cannot exceed %d characters", p.Name, maxAccessCodeLength)
+		}
+		if p.Allocation != nil && *p.Allocation < 1 {
+			return nil, fmt.Errorf("phase %q: allocation must be a positive integer", p.Name)
+		}
+		p.Sold = 0
+	}
+
+	saved, err := s.events.SetSalePhases(ctx, eventID, phases)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrAllocationsExceedCapacity) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("set sale phases: %w", err)
+	}
+	return saved, nil
+}
+
+// normalizeAccessCode trims and upper-cases a presale code as typed by an
+// attendee, matching how phase codes are stored.
+func normalizeAccessCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
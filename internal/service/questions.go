@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// Limits for registration forms and the attendee details given with them.
+const (
+	maxQuestions      = 50
+	maxOptions        = 50
+	maxLabelLength    = 200
+	maxNameLength     = 200
+	maxTextAnswerSize = 2000
+)
+
+// questionIDPattern keeps question ids usable as stable keys in answers,
+// exports and integrations.
+var questionIDPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+// GetQuestions returns an event's registration questions in form order.
+func (s *EventService) GetQuestions(ctx context.Context, eventID string) ([]model.Question, error) {
+	return s.questions(ctx, eventID)
+}
+
+// SetQuestions validates and replaces an event's registration questions.
+func (s *EventService) SetQuestions(ctx context.Context, eventID string, questions []model.Question) ([]model.Question, error) {
+	if questions == nil {
+		questions = []model.Question{}
+	}
+	if err := validateQuestions(questions); err != nil {
+		return nil, err
+	}
+	if err := s.events.SetQuestions(ctx, eventID, questions); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("set questions: %w", err)
+	}
+	return questions, nil
+}
+
+func (s *EventService) questions(ctx context.Context, eventID string) ([]model.Question, error) {
+	questions, err := s.events.Questions(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get questions: %w", err)
+	}
+	return questions, nil
+}
+
+// validateQuestions checks a form schema and trims its labels and options
+// in place.
+func validateQuestions(questions []model.Question) error {
+	if len(questions) > maxQuestions {
+		return fmt.Errorf("a form can have at most %d questions", maxQuestions)
+	}
+	seen := make(map[string]bool, len(questions))
+	for i := range questions {
+		q := &questions[i]
+		if !questionIDPattern.MatchString(q.ID) {
+			return fmt.Errorf("question %d: id must be 1-40 lower-case letters, digits or underscores, starting with a letter", i+1)
+		}
+		if seen[q.ID] {
+			return fmt.Errorf("question %d: id %q is used twice", i+1, q.ID)
+		}
+		seen[q.ID] = true
+
+		q.Label = strings.TrimSpace(q.Label)
+		if q.Label == "" || utf8.RuneCountInString(q.Label) > maxLabelLength {
+			return fmt.Errorf("question %q: label must be 1-%d characters", q.ID, maxLabelLength)
+		}
+		if !slices.Contains(model.QuestionTypes, q.Type) {
+			return fmt.Errorf("question %q: type must be one of %s", q.ID, strings.Join(model.QuestionTypes, ", "))
+		}
+
+		choice := q.Type == model.QuestionSingleChoice || q.Type == model.QuestionMultiChoice
+		if !choice {
+			if len(q.Options) > 0 {
+				return fmt.Errorf("question %q: only choice questions have options", q.ID)
+			}
+			q.Options = nil
+			continue
+		}
+		if len(q.Options) < 2 || len(q.Options) > maxOptions {
+			return fmt.Errorf("question %q: choice questions need 2-%d options", q.ID, maxOptions)
+		}
+		for j, opt := range q.Options {
+			opt = strings.TrimSpace(opt)
+			if opt == "" || utf8.RuneCountInString(opt) > maxLabelLength {
+				return fmt.Errorf("question %q: options must be 1-%d characters", q.ID, maxLabelLength)
+			}
+			if slices.Contains(q.Options[:j], opt) {
+				return fmt.Errorf("question %q: option %q is listed twice", q.ID, opt)
+			}
+			q.Options[j] = opt
+		}
+	}
+	return nil
+}
+
+// validateAnswers checks answers against an event's questions and returns
+// them normalized: text trimmed, multi-choice answers de-duplicated in
+// option order, and unanswered optional questions left out.  Answers to
+// questions the form does not have are rejected.
+func validateAnswers(questions []model.Question, answers model.Answers) (model.Answers, error) {
+	out := make(model.Answers, len(questions))
+	known := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		known[q.ID] = true
+		value, answered := answers[q.ID]
+		answered = answered && value != nil
+
+		if answered {
+			switch q.Type {
+			case model.QuestionText:
+				text, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("answer to %q must be text", q.Label)
+				}
+				text = strings.TrimSpace(text)
+				if utf8.RuneCountInString(text) > maxTextAnswerSize {
+					return nil, fmt.Errorf("answer to %q cannot exceed %d characters", q.Label, maxTextAnswerSize)
+				}
+				answered = text != ""
+				value = text
+
+			case model.QuestionSingleChoice:
+				choice, ok := value.(string)
+				if !ok || !slices.Contains(q.Options, choice) {
+					return nil, fmt.Errorf("answer to %q must be one of: %s", q.Label, strings.Join(q.Options, ", "))
+				}
+
+			case model.QuestionMultiChoice:
+				list, ok := value.([]any)
+				if !ok {
+					return nil, fmt.Errorf("answer to %q must be a list", q.Label)
+				}
+				var chosen []string
+				for _, opt := range q.Options {
+					if slices.Contains(list, any(opt)) {
+						chosen = append(chosen, opt)
+					}
+				}
+				for _, v := range list {
+					if s, ok := v.(string); !ok || !slices.Contains(q.Options, s) {
+						return nil, fmt.Errorf("answers to %q must be among: %s", q.Label, strings.Join(q.Options, ", "))
+					}
+				}
+				answered = len(chosen) > 0
+				value = chosen
+
+			case model.QuestionBoolean:
+				if _, ok := value.(bool); !ok {
+					return nil, fmt.Errorf("answer to %q must be true or false", q.Label)
+				}
+			}
+		}
+
+		if !answered {
+			if q.Required {
+				return nil, fmt.Errorf("%q is required", q.Label)
+			}
+			continue
+		}
+		out[q.ID] = value
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(answers)) {
+		if !known[id] {
+			return nil, fmt.Errorf("unknown question %q", id)
+		}
+	}
+	return out, nil
+}
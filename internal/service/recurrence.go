@@ -0,0 +1,167 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSeriesOccurrences bounds how many events one series rule may generate.
+const maxSeriesOccurrences = 200
+
+// rruleDays maps RFC 5545 weekday codes to their offset from Monday, the
+// first day of a recurrence week.
+var rruleDays = map[string]int{"MO": 0, "TU": 1, "WE": 2, "TH": 3, "FR": 4, "SA": 5, "SU": 6}
+
+// recurrence is a parsed weekly RRULE: every interval weeks on days, bounded
+// by until or count.
+type recurrence struct {
+	interval int
+	days     []int     // offsets from Monday, ascending; empty means the start's weekday
+	until    time.Time // zero when bounded by count
+	untilRaw string
+	count    int
+}
+
+// parseRRule parses the subset of RFC 5545 recurrence rules a series
+// supports: FREQ=WEEKLY with optional INTERVAL and BYDAY, and exactly one of
+// UNTIL or COUNT.  A floating or date-only UNTIL is read in loc; a date
+// includes the whole day.
+func parseRRule(rule string, loc *time.Location) (*recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("rrule is required")
+	}
+
+	r := &recurrence{interval: 1}
+	var freq string
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("rrule: malformed part %q", part)
+		}
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			freq = strings.ToUpper(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 52 {
+				return nil, fmt.Errorf("rrule: INTERVAL must be between 1 and 52")
+			}
+			r.interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(strings.ToUpper(value), ",") {
+				day, ok := rruleDays[code]
+				if !ok {
+					return nil, fmt.Errorf("rrule: unknown BYDAY value %q (use MO, TU, WE, TH, FR, SA, SU)", code)
+				}
+				r.days = append(r.days, day)
+			}
+		case "UNTIL":
+			until, err := parseRRuleUntil(value, loc)
+			if err != nil {
+				return nil, err
+			}
+			r.until, r.untilRaw = until, strings.ToUpper(value)
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > maxSeriesOccurrences {
+				return nil, fmt.Errorf("rrule: COUNT must be between 1 and %d", maxSeriesOccurrences)
+			}
+			r.count = n
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				return nil, fmt.Errorf("rrule: only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("rrule: unsupported part %q", name)
+		}
+	}
+	if freq != "WEEKLY" {
+		return nil, fmt.Errorf("rrule: only FREQ=WEEKLY is supported")
+	}
+	if r.until.IsZero() == (r.count == 0) {
+		return nil, fmt.Errorf("rrule: exactly one of UNTIL or COUNT is required")
+	}
+	slices.Sort(r.days)
+	r.days = slices.Compact(r.days)
+	return r, nil
+}
+
+// parseRRuleUntil accepts the three RFC 5545 UNTIL forms: UTC date-time,
+// floating date-time and date.
+func parseRRuleUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102", value, loc); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return time.Time{}, fmt.Errorf("rrule: UNTIL must look like 20261231 or 20261231T235959Z")
+}
+
+// String returns the rule in canonical form, as stored on the series.
+func (r *recurrence) String() string {
+	parts := []string{"FREQ=WEEKLY"}
+	if r.interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.interval))
+	}
+	if len(r.days) > 0 {
+		codes := make([]string, len(r.days))
+		for i, day := range r.days {
+			for code, d := range rruleDays {
+				if d == day {
+					codes[i] = code
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.count))
+	} else {
+		parts = append(parts, "UNTIL="+r.untilRaw)
+	}
+	return strings.Join(parts, ";")
+}
+
+// occurrences expands the rule from start: every matching day at start's
+// wall-clock time in loc, so a series keeps its local time across daylight
+// saving changes.  Weeks are counted from the Monday of start's week.
+func (r *recurrence) occurrences(start time.Time, loc *time.Location) ([]time.Time, error) {
+	local := start.In(loc)
+	days := r.days
+	if len(days) == 0 {
+		days = []int{(int(local.Weekday()) + 6) % 7}
+	}
+	monday := local.Day() - (int(local.Weekday())+6)%7
+
+	var out []time.Time
+	for week := 0; ; week += r.interval {
+		for _, day := range days {
+			t := time.Date(local.Year(), local.Month(), monday+7*week+day,
+				local.Hour(), local.Minute(), local.Second(), 0, loc)
+			if t.Before(local.Truncate(time.Second)) {
+				continue
+			}
+			if !r.until.IsZero() && t.After(r.until) {
+				if len(out) == 0 {
+					return nil, fmt.Errorf("rrule yields no occurrences after starts_at")
+				}
+				return out, nil
+			}
+			if len(out) == maxSeriesOccurrences {
+				return nil, fmt.Errorf("rrule yields more than %d occurrences", maxSeriesOccurrences)
+			}
+			out = append(out, t.UTC())
+			if len(out) == r.count {
+				return out, nil
+			}
+		}
+	}
+}
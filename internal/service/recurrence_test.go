@@ -0,0 +1,197 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		want  []string
+	}{
+		{
+			name:  "count",
+			rule:  "FREQ=WEEKLY;COUNT=3",
+			start: "2026-01-05T18:00:00Z", // a Monday
+			want:  []string{"2026-01-05T18:00:00Z", "2026-01-12T18:00:00Z", "2026-01-19T18:00:00Z"},
+		},
+		{
+			name:  "until date includes the whole day",
+			rule:  "FREQ=WEEKLY;UNTIL=20260119",
+			start: "2026-01-05T18:00:00Z",
+			want:  []string{"2026-01-05T18:00:00Z", "2026-01-12T18:00:00Z", "2026-01-19T18:00:00Z"},
+		},
+		{
+			name:  "until date-time excludes a later start",
+			rule:  "FREQ=WEEKLY;UNTIL=20260119T170000Z",
+			start: "2026-01-05T18:00:00Z",
+			want:  []string{"2026-01-05T18:00:00Z", "2026-01-12T18:00:00Z"},
+		},
+		{
+			name:  "until equal to an occurrence includes it",
+			rule:  "FREQ=WEEKLY;UNTIL=20260112T180000Z",
+			start: "2026-01-05T18:00:00Z",
+			want:  []string{"2026-01-05T18:00:00Z", "2026-01-12T18:00:00Z"},
+		},
+		{
+			name:  "byday skips days before the start",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=4",
+			start: "2026-01-09T10:00:00Z", // a Friday
+			want:  []string{"2026-01-09T10:00:00Z", "2026-01-12T10:00:00Z", "2026-01-16T10:00:00Z", "2026-01-19T10:00:00Z"},
+		},
+		{
+			name:  "sunday ends the week",
+			rule:  "FREQ=WEEKLY;BYDAY=SU,MO;COUNT=3",
+			start: "2026-01-10T10:00:00Z", // a Saturday
+			want:  []string{"2026-01-11T10:00:00Z", "2026-01-12T10:00:00Z", "2026-01-18T10:00:00Z"},
+		},
+		{
+			name:  "byday across a month boundary",
+			rule:  "FREQ=WEEKLY;BYDAY=FR,MO;COUNT=3",
+			start: "2026-01-30T10:00:00Z", // a Friday
+			want:  []string{"2026-01-30T10:00:00Z", "2026-02-02T10:00:00Z", "2026-02-06T10:00:00Z"},
+		},
+		{
+			name:  "byday across a year boundary",
+			rule:  "FREQ=WEEKLY;BYDAY=WE;UNTIL=20270107",
+			start: "2026-12-23T09:00:00Z",
+			want:  []string{"2026-12-23T09:00:00Z", "2026-12-30T09:00:00Z", "2027-01-06T09:00:00Z"},
+		},
+		{
+			name:  "interval counts weeks from the start's week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=4",
+			start: "2026-01-05T18:00:00Z",
+			want:  []string{"2026-01-06T18:00:00Z", "2026-01-08T18:00:00Z", "2026-01-20T18:00:00Z", "2026-01-22T18:00:00Z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseRRule(tt.rule, time.UTC)
+			if err != nil {
+				t.Fatalf("parseRRule(%q): %v", tt.rule, err)
+			}
+			got, err := r.occurrences(at(tt.start), time.UTC)
+			if err != nil {
+				t.Fatalf("occurrences: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %v", len(got), got, tt.want)
+			}
+			for i, w := range tt.want {
+				if !got[i].Equal(at(w)) {
+					t.Errorf("occurrence %d = %v, want %s", i, got[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceKeepsLocalTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+	r, err := parseRRule("FREQ=WEEKLY;COUNT=2", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 18:00 EST, then 18:00 EDT after clocks go forward on 2026-03-08.
+	got, err := r.occurrences(time.Date(2026, 3, 6, 23, 0, 0, 0, time.UTC), loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []time.Time{
+		time.Date(2026, 3, 6, 23, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 13, 22, 0, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) || !got[0].Equal(want[0]) || !got[1].Equal(want[1]) {
+		t.Errorf("occurrences = %v, want %v", got, want)
+	}
+}
+
+func TestRecurrenceOccurrenceCap(t *testing.T) {
+	start := time.Date(2026, 1, 5, 18, 0, 0, 0, time.UTC)
+	everyDay := "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR,SA,SU"
+
+	r, err := parseRRule(everyDay+";COUNT=200", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.occurrences(start, time.UTC)
+	if err != nil {
+		t.Fatalf("COUNT at the cap: %v", err)
+	}
+	if len(got) != maxSeriesOccurrences {
+		t.Errorf("COUNT at the cap gave %d occurrences, want %d", len(got), maxSeriesOccurrences)
+	}
+
+	if _, err := parseRRule(everyDay+";COUNT=201", time.UTC); err == nil {
+		t.Error("COUNT over the cap: want a parse error")
+	}
+
+	r, err = parseRRule(everyDay+";UNTIL=20270101", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.occurrences(start, time.UTC); err == nil || !strings.Contains(err.Error(), "more than") {
+		t.Errorf("UNTIL past the cap: err = %v, want the cap error", err)
+	}
+
+	r, err = parseRRule("FREQ=WEEKLY;UNTIL=20260101", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.occurrences(start, time.UTC); err == nil {
+		t.Error("UNTIL before the start: want an error")
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	for rule, want := range map[string]string{
+		"RRULE:FREQ=WEEKLY;COUNT=5":                     "FREQ=WEEKLY;COUNT=5",
+		"freq=weekly;byday=fr,mo,fr;until=20261231":     "FREQ=WEEKLY;BYDAY=MO,FR;UNTIL=20261231",
+		"FREQ=WEEKLY;INTERVAL=1;WKST=MO;COUNT=2":        "FREQ=WEEKLY;COUNT=2",
+		"FREQ=WEEKLY;INTERVAL=3;UNTIL=20261231T235959Z": "FREQ=WEEKLY;INTERVAL=3;UNTIL=20261231T235959Z",
+	} {
+		r, err := parseRRule(rule, time.UTC)
+		if err != nil {
+			t.Errorf("parseRRule(%q): %v", rule, err)
+			continue
+		}
+		if got := r.String(); got != want {
+			t.Errorf("parseRRule(%q).String() = %q, want %q", rule, got, want)
+		}
+	}
+
+	for _, rule := range []string{
+		"",
+		"FREQ=WEEKLY",
+		"FREQ=WEEKLY;COUNT=3;UNTIL=20261231",
+		"FREQ=DAILY;COUNT=3",
+		"FREQ=WEEKLY;COUNT=0",
+		"FREQ=WEEKLY;INTERVAL=0;COUNT=3",
+		"FREQ=WEEKLY;INTERVAL=53;COUNT=3",
+		"FREQ=WEEKLY;BYDAY=XX;COUNT=3",
+		"FREQ=WEEKLY;WKST=SU;COUNT=3",
+		"FREQ=WEEKLY;UNTIL=2026-12-31",
+		"FREQ=WEEKLY;BYMONTH=1;COUNT=3",
+		"FREQ=WEEKLY;COUNT",
+	} {
+		if _, err := parseRRule(rule, time.UTC); err == nil {
+			t.Errorf("parseRRule(%q): want an error", rule)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/payment"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// Limits on an event's refund policy.
+const (
+	maxRefundRules      = 10
+	maxRefundDaysBefore = 3650
+)
+
+// Refund attempts: a claimed refund is leased for refundLease, and a failed
+// attempt is retried after refundRetryBase, doubling up to refundRetryCap,
+// until maxRefundAttempts have failed.
+const (
+	refundLease       = 5 * time.Minute
+	refundRetryBase   = time.Minute
+	refundRetryCap    = time.Hour
+	maxRefundAttempts = 10
+)
+
+// GetRefundPolicy returns an event's refund rules, most days before first.
+func (s *EventService) GetRefundPolicy(ctx context.Context, eventID string) ([]model.RefundRule, error) {
+	rules, err := s.events.RefundPolicy(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get refund policy: %w", err)
+	}
+	return rules, nil
+}
+
+// SetRefundPolicy validates and replaces an event's refund rules.  An empty
+// list refunds nothing.  Cancelling later may never refund more than
+// cancelling earlier, so percent cannot rise as days_before falls.
+func (s *EventService) SetRefundPolicy(ctx context.Context, eventID string, rules []model.RefundRule) ([]model.RefundRule, error) {
+	if len(rules) > maxRefundRules {
+		return nil, fmt.Errorf("a refund policy can have at most %d rules", maxRefundRules)
+	}
+	for i, r := range rules {
+		if r.DaysBefore < 0 || r.DaysBefore > maxRefundDaysBefore {
+			return nil, fmt.Errorf("rule %d: days_before must be between 0 and %d", i+1, maxRefundDaysBefore)
+		}
+		if r.Percent < 0 || r.Percent > 100 {
+			return nil, fmt.Errorf("rule %d: percent must be between 0 and 100", i+1)
+		}
+	}
+	sorted := slices.Clone(rules)
+	slices.SortFunc(sorted, func(a, b model.RefundRule) int { return b.DaysBefore - a.DaysBefore })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].DaysBefore == sorted[i-1].DaysBefore {
+			return nil, fmt.Errorf("days_before %d is listed twice", sorted[i].DaysBefore)
+		}
+		if sorted[i].Percent > sorted[i-1].Percent {
+			return nil, fmt.Errorf("the rule for %d days before cannot refund more than the rule for %d days before",
+				sorted[i].DaysBefore, sorted[i-1].DaysBefore)
+		}
+	}
+
+	saved, err := s.events.SetRefundPolicy(ctx, eventID, sorted)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("set refund policy: %w", err)
+	}
+	return saved, nil
+}
+
+// issueRefund claims the refund with id, or when id is empty the
+// longest-due refund, and asks the payment provider to pay it.  The outcome
+// is recorded either way: a declined refund is due again after a backoff,
+// and fails for good after maxRefundAttempts.  It returns nil when nothing
+// could be claimed.
+func (s *EventService) issueRefund(ctx context.Context, id string) (*model.Refund, error) {
+	refund, order, err := s.orders.ClaimRefund(ctx, id, refundLease)
+	if err != nil || refund == nil {
+		return nil, err
+	}
+
+	var p *payment.Refund
+	if order.Provider != s.payments.Provider.Name() || order.PaymentID == nil {
+		err = fmt.Errorf("order was not paid with the configured provider %q", s.payments.Provider.Name())
+	} else {
+		p, err = s.payments.Provider.Refund(ctx, payment.RefundRequest{
+			RefundID:    refund.ID,
+			PaymentID:   *order.PaymentID,
+			AmountCents: refund.AmountCents,
+			Currency:    refund.Currency,
+		})
+	}
+	if err == nil {
+		return s.orders.CompleteRefund(ctx, refund.ID, p.ID)
+	}
+
+	log.Printf("refund %s: attempt %d/%d: %v", refund.ID, refund.Attempts, maxRefundAttempts, err)
+	var retryAt *time.Time
+	if refund.Attempts < maxRefundAttempts {
+		at := time.Now().UTC().Add(refundBackoff(refund.Attempts))
+		retryAt = &at
+	}
+	return s.orders.FailRefund(ctx, refund.ID, err, retryAt)
+}
+
+// refundBackoff returns the delay before retrying a refund that has failed
+// attempts times.
+func refundBackoff(attempts int) time.Duration {
+	d := refundRetryBase
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= refundRetryCap {
+			return refundRetryCap
+		}
+	}
+	return d
+}
+
+// ProcessRefunds attempts every refund that is due.  It is run by the job
+// runner, and picks up refunds whose first attempt failed or never ran.
+func (s *EventService) ProcessRefunds(ctx context.Context) (int, error) {
+	processed := 0
+	for {
+		refund, err := s.issueRefund(ctx, "")
+		if err != nil {
+			return processed, fmt.Errorf("issue refund: %w", err)
+		}
+		if refund == nil {
+			return processed, nil
+		}
+		processed++
+	}
+}
+
+// RetryRefund makes a failed refund due again with a fresh attempt budget
+// and attempts it at once.
+func (s *EventService) RetryRefund(ctx context.Context, id string) (*model.Refund, error) {
+	refund, err := s.orders.RetryRefund(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrRefundNotRetryable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("retry refund: %w", err)
+	}
+	if issued, err := s.issueRefund(ctx, refund.ID); err != nil {
+		return nil, fmt.Errorf("issue refund: %w", err)
+	} else if issued != nil {
+		refund = issued
+	}
+	return refund, nil
+}
+
+// ListRefunds returns an event's refunds, oldest first, optionally only
+// those in status.
+func (s *EventService) ListRefunds(ctx context.Context, eventID, status string) ([]model.Refund, error) {
+	if status != "" && !slices.Contains(model.RefundStatuses, status) {
+		return nil, fmt.Errorf("status must be one of %v", model.RefundStatuses)
+	}
+	refunds, err := s.orders.ListRefunds(ctx, eventID, status)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("list refunds: %w", err)
+	}
+	return refunds, nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// Limits on a venue's seat map.
+const (
+	maxSeatsPerMap     = 100_000
+	maxSectionLength   = 50
+	maxSeatLabelLength = 10
+)
+
+// GetSeatMap returns a venue's seat map.
+func (s *VenueService) GetSeatMap(ctx context.Context, venueID string) (*model.SeatMap, error) {
+	m, err := s.venues.SeatMap(ctx, venueID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get seat map: %w", err)
+	}
+	return m, nil
+}
+
+// SetSeatMap validates and replaces a venue's seat map.  Section names,
+// row labels within a section and seat labels within a row must be unique;
+// seat ids in the request are ignored.  An empty map removes every seat.
+func (s *VenueService) SetSeatMap(ctx context.Context, venueID string, m model.SeatMap) (*model.SeatMap, error) {
+	if err := validateSeatMap(&m); err != nil {
+		return nil, err
+	}
+	saved, err := s.venues.SetSeatMap(ctx, venueID, m)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrSeatInUse) ||
+			errors.Is(err, repository.ErrNotEnoughSeats) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("set seat map: %w", err)
+	}
+	return saved, nil
+}
+
+// validateSeatMap checks a seat map and trims its names in place.
+func validateSeatMap(m *model.SeatMap) error {
+	if m.Count() > maxSeatsPerMap {
+		return fmt.Errorf("a seat map can have at most %d seats", maxSeatsPerMap)
+	}
+	var sections []string
+	for i := range m.Sections {
+		sec := &m.Sections[i]
+		sec.Name = strings.TrimSpace(sec.Name)
+		if sec.Name == "" || utf8.RuneCountInString(sec.Name) > maxSectionLength {
+			return fmt.Errorf("section %d: name must be 1-%d characters", i+1, maxSectionLength)
+		}
+		if slices.Contains(sections, sec.Name) {
+			return fmt.Errorf("section %q is listed twice", sec.Name)
+		}
+		sections = append(sections, sec.Name)
+
+		var rows []string
+		for j := range sec.Rows {
+			row := &sec.Rows[j]
+			row.Label = strings.TrimSpace(row.Label)
+			if row.Label == "" || utf8.RuneCountInString(row.Label) > maxSeatLabelLength {
+				return fmt.Errorf("section %q, row %d: label must be 1-%d characters", sec.Name, j+1, maxSeatLabelLength)
+			}
+			if slices.Contains(rows, row.Label) {
+				return fmt.Errorf("section %q: row %q is listed twice", sec.Name, row.Label)
+			}
+			rows = append(rows, row.Label)
+
+			seen := make(map[string]bool, len(row.Seats))
+			for k := range row.Seats {
+				seat := &row.Seats[k]
+				seat.Label = strings.TrimSpace(seat.Label)
+				if seat.Label == "" || utf8.RuneCountInString(seat.Label) > maxSeatLabelLength {
+					return fmt.Errorf("section %q, row %q, seat %d: label must be 1-%d characters",
+						sec.Name, row.Label, k+1, maxSeatLabelLength)
+				}
+				if seen[seat.Label] {
+					return fmt.Errorf("section %q, row %q: seat %q is listed twice", sec.Name, row.Label, seat.Label)
+				}
+				seen[seat.Label] = true
+				seat.ID, seat.Status = "", ""
+			}
+		}
+	}
+	return nil
+}
+
+// GetSeats returns the seat map of a reserved-seating event's venue with
+// each seat's availability for the event.
+func (s *EventService) GetSeats(ctx context.Context, eventID string) (*model.SeatMap, error) {
+	m, err := s.events.Seats(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrNotReservedSeating) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get seats: %w", err)
+	}
+	return m, nil
+}
+
+// isSeatError reports whether err is a seat choice a booking rejected.
+func isSeatError(err error) bool {
+	return errors.Is(err, repository.ErrSeatRequired) ||
+		errors.Is(err, repository.ErrSeatNotFound) ||
+		errors.Is(err, repository.ErrSeatTaken)
+}
+
+// normalizeSeatID trims a seat id as sent by a client, or returns nil if
+// none was sent.
+func normalizeSeatID(id string) *string {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil
+	}
+	return &id
+}
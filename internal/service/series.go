@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// SeriesService manages recurring event series.
+type SeriesService struct {
+	series *repository.SeriesRepository
+	events *repository.EventRepository
+}
+
+// NewSeriesService constructs a SeriesService.
+func NewSeriesService(series *repository.SeriesRepository, events *repository.EventRepository) *SeriesService {
+	return &SeriesService{series: series, events: events}
+}
+
+// CreateSeries validates the template and rule and generates every
+// occurrence up front, so each one can be booked and edited like any event.
+func (s *SeriesService) CreateSeries(ctx context.Context, req model.CreateSeriesRequest) (*model.EventSeries, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("series name is required")
+	}
+	if req.Capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be a positive integer")
+	}
+	if req.Capacity > 100_000 {
+		return nil, fmt.Errorf("capacity cannot exceed 100,000")
+	}
+	if req.StartsAt == nil {
+		return nil, fmt.Errorf("starts_at is required")
+	}
+	if err := validateSchedule(req.StartsAt, req.EndsAt); err != nil {
+		return nil, err
+	}
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if req.VenueID != nil && *req.VenueID == "" {
+		req.VenueID = nil
+	}
+
+	loc, err := loadTimezone(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := parseRRule(req.RRule, loc)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := rule.occurrences(*req.StartsAt, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := s.series.Create(ctx, &model.EventSeries{
+		Name:        req.Name,
+		Description: req.Description,
+		Capacity:    req.Capacity,
+		VenueID:     req.VenueID,
+		Tags:        tags,
+		RRule:       rule.String(),
+		Timezone:    loc.String(),
+		StartsAt:    *req.StartsAt,
+		EndsAt:      req.EndsAt,
+	}, starts)
+	if err != nil {
+		if errors.Is(err, repository.ErrVenueNotFound) || errors.Is(err, repository.ErrVenueCapacityExceeded) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("create series: %w", err)
+	}
+	return series, nil
+}
+
+// GetSeries returns a series and its occurrences.
+func (s *SeriesService) GetSeries(ctx context.Context, id string) (*model.EventSeries, error) {
+	return s.series.Get(ctx, id)
+}
+
+// UpdateOccurrences edits the occurrence eventID of a series alone (scope
+// "this", the default) or together with every later occurrence and the
+// series template (scope "future").  It returns the updated series.
+func (s *SeriesService) UpdateOccurrences(ctx context.Context, seriesID, eventID, scope string, req model.UpdateSeriesRequest) (*model.EventSeries, error) {
+	if err := validateEventUpdate(&req.UpdateEventRequest); err != nil {
+		return nil, err
+	}
+
+	switch scope {
+	case "", model.SeriesScopeThis:
+		if req.RRule != nil || req.Timezone != nil {
+			return nil, fmt.Errorf("rrule and timezone can only be changed with scope=%s", model.SeriesScopeFuture)
+		}
+		event, err := s.events.GetByID(ctx, eventID)
+		if err != nil {
+			return nil, err
+		}
+		if event.SeriesID == nil || *event.SeriesID != seriesID {
+			return nil, repository.ErrNotFound
+		}
+		if _, err = s.events.Update(ctx, eventID, req.UpdateEventRequest); err != nil {
+			if isEventUpdateError(err) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("update occurrence: %w", err)
+		}
+		return s.series.Get(ctx, seriesID)
+
+	case model.SeriesScopeFuture:
+		var rule *repository.SeriesRule
+		if req.RRule != nil || req.Timezone != nil {
+			var err error
+			if rule, err = s.newRule(ctx, seriesID, req.RRule, req.Timezone); err != nil {
+				return nil, err
+			}
+		}
+		series, err := s.series.UpdateFuture(ctx, seriesID, eventID, req.UpdateEventRequest, rule)
+		if err != nil {
+			if isEventUpdateError(err) || errors.Is(err, repository.ErrOccurrencesBooked) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("update occurrences: %w", err)
+		}
+		return series, nil
+
+	default:
+		return nil, fmt.Errorf("scope must be %q or %q", model.SeriesScopeThis, model.SeriesScopeFuture)
+	}
+}
+
+// newRule parses a replacement rule and timezone; whichever is nil keeps the
+// series' current value.
+func (s *SeriesService) newRule(ctx context.Context, seriesID string, rrule, timezone *string) (*repository.SeriesRule, error) {
+	if rrule == nil || timezone == nil {
+		current, err := s.series.Get(ctx, seriesID)
+		if err != nil {
+			return nil, err
+		}
+		if rrule == nil {
+			rrule = &current.RRule
+		}
+		if timezone == nil {
+			timezone = &current.Timezone
+		}
+	}
+
+	loc, err := loadTimezone(*timezone)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := parseRRule(*rrule, loc)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.SeriesRule{
+		RRule:    rule.String(),
+		Timezone: loc.String(),
+		Expand: func(from time.Time) ([]time.Time, error) {
+			return rule.occurrences(from, loc)
+		},
+	}, nil
+}
+
+// DeleteOccurrences deletes the occurrence eventID of a series, or with scope
+// "future" it and every later one.  Occurrences with registrations or
+// orders are never deleted: the whole request fails with
+// repository.ErrOccurrencesBooked.
+func (s *SeriesService) DeleteOccurrences(ctx context.Context, seriesID, eventID, scope string) error {
+	if scope != "" && scope != model.SeriesScopeThis && scope != model.SeriesScopeFuture {
+		return fmt.Errorf("scope must be %q or %q", model.SeriesScopeThis, model.SeriesScopeFuture)
+	}
+	err := s.series.DeleteOccurrences(ctx, seriesID, eventID, scope == model.SeriesScopeFuture)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrOccurrencesBooked) {
+			return err
+		}
+		return fmt.Errorf("delete occurrences: %w", err)
+	}
+	return nil
+}
+
+// loadTimezone resolves an IANA timezone name; empty means UTC.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return loc, nil
+}
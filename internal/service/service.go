@@ -6,7 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
 	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
@@ -16,14 +20,35 @@ import (
 type EventService struct {
 	events        *repository.EventRepository
 	registrations *repository.RegistrationRepository
+	applications  *repository.ApplicationRepository
+	ballots       *repository.BallotRepository
+	invites       *repository.InviteRepository
+	allocations   *repository.AllocationRepository
+	orders        *repository.OrderRepository
+	payments      PaymentConfig
 }
 
 // NewEventService constructs an EventService with its dependencies.
 func NewEventService(
 	events *repository.EventRepository,
 	registrations *repository.RegistrationRepository,
+	applications *repository.ApplicationRepository,
+	ballots *repository.BallotRepository,
+	invites *repository.InviteRepository,
+	allocations *repository.AllocationRepository,
+	orders *repository.OrderRepository,
+	payments PaymentConfig,
 ) *EventService {
-	return &EventService{events: events, registrations: registrations}
+	return &EventService{
+		events:        events,
+		registrations: registrations,
+		applications:  applications,
+		ballots:       ballots,
+		invites:       invites,
+		allocations:   allocations,
+		orders:        orders,
+		payments:      payments,
+	}
 }
 
 // CreateEvent validates the request and delegates to the repository.
@@ -38,12 +63,265 @@ func (s *EventService) CreateEvent(ctx context.Context, req model.CreateEventReq
 	if req.Capacity > 100_000 {
 		return nil, fmt.Errorf("capacity cannot exceed 100,000")
 	}
+	if err := validateSchedule(req.StartsAt, req.EndsAt); err != nil {
+		return nil, err
+	}
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	req.Tags = tags
+	if req.VenueID != nil && *req.VenueID == "" {
+		req.VenueID = nil
+	}
+	if req.MaxGroupSize == 0 {
+		req.MaxGroupSize = model.DefaultMaxGroupSize
+	}
+	if err := validateMaxGroupSize(req.MaxGroupSize); err != nil {
+		return nil, err
+	}
+	if req.Visibility == "" {
+		req.Visibility = model.VisibilityPublic
+	}
+	if err := validateVisibility(req.Visibility); err != nil {
+		return nil, err
+	}
+	if err := validateOversell(req.OversellPercent, req.OversellSeats); err != nil {
+		return nil, err
+	}
+	if err := validatePrice(req.PriceCents); err != nil {
+		return nil, err
+	}
+	if req.Currency == "" {
+		req.Currency = model.DefaultCurrency
+	}
+	if err := normalizeCurrency(&req.Currency); err != nil {
+		return nil, err
+	}
 	return s.events.Create(ctx, req)
 }
 
-// ListEvents returns all events.
-func (s *EventService) ListEvents(ctx context.Context) ([]model.Event, error) {
-	return s.events.List(ctx)
+// UpdateEvent validates a partial update and delegates to the repository,
+// which applies it under the event row lock.
+func (s *EventService) UpdateEvent(ctx context.Context, id string, req model.UpdateEventRequest) (*model.Event, error) {
+	if err := validateEventUpdate(&req); err != nil {
+		return nil, err
+	}
+
+	event, err := s.events.Update(ctx, id, req)
+	if err != nil {
+		if isEventUpdateError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("update event: %w", err)
+	}
+	return event, nil
+}
+
+// validateEventUpdate checks and normalizes the fields of a partial update.
+func validateEventUpdate(req *model.UpdateEventRequest) error {
+	if req.Name != nil {
+		*req.Name = strings.TrimSpace(*req.Name)
+		if *req.Name == "" {
+			return fmt.Errorf("event name cannot be empty")
+		}
+	}
+	if req.Capacity != nil {
+		if *req.Capacity <= 0 {
+			return fmt.Errorf("capacity must be a positive integer")
+		}
+		if *req.Capacity > 100_000 {
+			return fmt.Errorf("capacity cannot exceed 100,000")
+		}
+	}
+	if req.StartsAt != nil {
+		*req.StartsAt = req.StartsAt.UTC()
+	}
+	if req.EndsAt != nil {
+		*req.EndsAt = req.EndsAt.UTC()
+	}
+	if req.Tags != nil {
+		tags, err := normalizeTags(*req.Tags)
+		if err != nil {
+			return err
+		}
+		req.Tags = &tags
+	}
+	if req.MaxGroupSize != nil {
+		if err := validateMaxGroupSize(*req.MaxGroupSize); err != nil {
+			return err
+		}
+	}
+	if req.Visibility != nil {
+		if err := validateVisibility(*req.Visibility); err != nil {
+			return err
+		}
+	}
+	if req.OversellPercent != nil || req.OversellSeats != nil {
+		var percent, seats int
+		if req.OversellPercent != nil {
+			percent = *req.OversellPercent
+		}
+		if req.OversellSeats != nil {
+			seats = *req.OversellSeats
+		}
+		if err := validateOversell(percent, seats); err != nil {
+			return err
+		}
+	}
+	if req.PriceCents != nil {
+		if err := validatePrice(*req.PriceCents); err != nil {
+			return err
+		}
+	}
+	if req.Currency != nil {
+		if err := normalizeCurrency(req.Currency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxPriceCents caps a ticket's price, in the currency's minor unit.
+const maxPriceCents = 100_000_000
+
+func validatePrice(cents int) error {
+	if cents < 0 || cents > maxPriceCents {
+		return fmt.Errorf("price_cents must be between 0 and %d", maxPriceCents)
+	}
+	return nil
+}
+
+// normalizeCurrency upper-cases a currency code and checks that it has the
+// form of an ISO 4217 code.
+func normalizeCurrency(currency *string) error {
+	*currency = strings.ToUpper(strings.TrimSpace(*currency))
+	if len(*currency) != 3 || strings.Trim(*currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("currency must be a three-letter ISO 4217 code")
+	}
+	return nil
+}
+
+// maxOversellPercent caps the oversell allowance at double the room.
+const maxOversellPercent = 100
+
+// validateOversell checks an oversell allowance, given as a percentage of
+// capacity or as a number of seats but not both.
+func validateOversell(percent, seats int) error {
+	if percent < 0 || percent > maxOversellPercent {
+		return fmt.Errorf("oversell_percent must be between 0 and %d", maxOversellPercent)
+	}
+	if seats < 0 || seats > 100_000 {
+		return fmt.Errorf("oversell_seats must be between 0 and 100,000")
+	}
+	if percent > 0 && seats > 0 {
+		return fmt.Errorf("set oversell_percent or oversell_seats, not both")
+	}
+	return nil
+}
+
+func validateVisibility(v string) error {
+	if !slices.Contains(model.Visibilities, v) {
+		return fmt.Errorf("visibility must be one of %v", model.Visibilities)
+	}
+	return nil
+}
+
+// maxGroupSizeLimit is the largest max_group_size an organizer may set.
+const maxGroupSizeLimit = 1000
+
+func validateMaxGroupSize(n int) error {
+	if n < 1 || n > maxGroupSizeLimit {
+		return fmt.Errorf("max_group_size must be between 1 and %d", maxGroupSizeLimit)
+	}
+	return nil
+}
+
+// isEventUpdateError reports whether err is a domain error an event update
+// returns to the caller as is.
+func isEventUpdateError(err error) bool {
+	return errors.Is(err, repository.ErrNotFound) ||
+		errors.Is(err, repository.ErrCapacityBelowBooked) ||
+		errors.Is(err, repository.ErrInvalidSchedule) ||
+		errors.Is(err, repository.ErrVenueNotFound) ||
+		errors.Is(err, repository.ErrVenueCapacityExceeded) ||
+		errors.Is(err, repository.ErrNoSeatMap) ||
+		errors.Is(err, repository.ErrNotEnoughSeats) ||
+		errors.Is(err, repository.ErrSeatingConflict) ||
+		errors.Is(err, repository.ErrSeatsAssigned) ||
+		errors.Is(err, repository.ErrPricingConflict)
+}
+
+// ListEvents returns one page of events matching search, in its sort order.
+// The sort defaults to relevance when there is a query and newest otherwise.
+func (s *EventService) ListEvents(ctx context.Context, search model.EventSearch, page model.PageRequest) (*model.Page[model.Event], error) {
+	search.Query = strings.TrimSpace(search.Query)
+	if search.Sort == "" {
+		switch {
+		case search.Query != "":
+			search.Sort = model.EventSortRelevance
+		case search.Near != nil:
+			search.Sort = model.EventSortDistance
+		default:
+			search.Sort = model.EventSortNewest
+		}
+	}
+	if !slices.Contains(model.EventSorts, search.Sort) {
+		return nil, fmt.Errorf("%w: sort must be one of %v", repository.ErrInvalidQuery, model.EventSorts)
+	}
+	if search.Sort == model.EventSortRelevance && search.Query == "" {
+		return nil, fmt.Errorf("%w: sort=relevance requires a search query (q)", repository.ErrInvalidQuery)
+	}
+	if search.Near == nil {
+		if search.Sort == model.EventSortDistance || search.RadiusKM != 0 {
+			return nil, fmt.Errorf("%w: sort=distance and radius_km require near", repository.ErrInvalidQuery)
+		}
+	} else {
+		if search.Near.Latitude < -90 || search.Near.Latitude > 90 ||
+			search.Near.Longitude < -180 || search.Near.Longitude > 180 {
+			return nil, fmt.Errorf("%w: near must be a valid latitude,longitude", repository.ErrInvalidQuery)
+		}
+		if search.RadiusKM == 0 {
+			search.RadiusKM = defaultRadiusKM
+		}
+		if search.RadiusKM < 0 || search.RadiusKM > maxRadiusKM {
+			return nil, fmt.Errorf("%w: radius_km must be between 0 and %d", repository.ErrInvalidQuery, maxRadiusKM)
+		}
+	}
+	switch search.Availability {
+	case "", model.AvailabilityOpen, model.AvailabilitySoldOut:
+	default:
+		return nil, fmt.Errorf("%w: availability must be %q or %q", repository.ErrInvalidQuery, model.AvailabilityOpen, model.AvailabilitySoldOut)
+	}
+	tags, err := normalizeTags(search.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrInvalidQuery, err)
+	}
+	search.Tags = tags
+	if search.StartsFrom != nil && search.StartsBefore != nil && !search.StartsBefore.After(*search.StartsFrom) {
+		return nil, fmt.Errorf("%w: date range end must be after its start", repository.ErrInvalidQuery)
+	}
+
+	limit, err := validatePageLimit(page.Limit)
+	if err != nil {
+		return nil, err
+	}
+	after, err := repository.DecodeCursor(page.Cursor, search.Sort)
+	if err != nil {
+		return nil, err
+	}
+	events, next, err := s.events.List(ctx, search, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(events, next), nil
+}
+
+// ListAllEvents returns every event, newest first, in one slice.  It backs
+// the legacy array response and should not be used for new callers.
+func (s *EventService) ListAllEvents(ctx context.Context) ([]model.Event, error) {
+	events, _, err := s.events.List(ctx, model.EventSearch{Sort: model.EventSortNewest}, nil, 0)
+	return events, err
 }
 
 // GetEvent returns a single event by ID.
@@ -62,8 +340,13 @@ func (s *EventService) GetEvent(ctx context.Context, id string) (*model.Event, e
 }
 
 // Register validates the registration request and delegates the concurrency-safe
-// booking to the repository layer.
-func (s *EventService) Register(ctx context.Context, eventID string, req model.RegisterRequest) (*model.Registration, error) {
+// booking to the repository layer.  For events that require approval it
+// files a pending application instead, and while a ballot is open it enters
+// the ballot; neither holds a seat.  For paid events it places an order
+// that holds the seat until it is paid.  The event's eligibility rules are
+// checked first, whichever way it books.  Invite-only events spend a use of the
+// request's invite code with the seat.
+func (s *EventService) Register(ctx context.Context, eventID string, req model.RegisterRequest) (*model.RegisterResult, error) {
 	req.UserEmail = strings.TrimSpace(strings.ToLower(req.UserEmail))
 	if req.UserEmail == "" {
 		return nil, fmt.Errorf("user_email is required")
@@ -74,26 +357,383 @@ func (s *EventService) Register(ctx context.Context, eventID string, req model.R
 	if eventID == "" {
 		return nil, fmt.Errorf("event id is required")
 	}
-
-	reg, err := s.registrations.Book(ctx, eventID, req.UserEmail)
+	req.Name = strings.TrimSpace(req.Name)
+	if utf8.RuneCountInString(req.Name) > maxNameLength {
+		return nil, fmt.Errorf("name cannot exceed %d characters", maxNameLength)
+	}
+	questions, err := s.questions(ctx, eventID)
 	if err != nil {
+		return nil, err
+	}
+	answers, err := validateAnswers(questions, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEligibility(ctx, eventID, req.UserEmail, answers, nil); err != nil {
+		return nil, err
+	}
+
+	reg, err := s.registrations.Book(ctx, eventID, &model.Registration{
+		UserEmail:  req.UserEmail,
+		Name:       req.Name,
+		InviteCode: normalizeInviteCode(req.InviteCode),
+		SeatID:     normalizeSeatID(req.SeatID),
+		Answers:    answers,
+	}, normalizeAccessCode(req.AccessCode))
+	switch {
+	case errors.Is(err, repository.ErrApprovalRequired):
+		app, err := s.apply(ctx, eventID, &model.Application{
+			UserEmail: req.UserEmail,
+			Name:      req.Name,
+			Answers:   answers,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &model.RegisterResult{Application: app}, nil
+	case errors.Is(err, repository.ErrBallotOpen):
+		entry, err := s.enterBallot(ctx, eventID, &model.BallotEntry{
+			UserEmail: req.UserEmail,
+			Name:      req.Name,
+			Answers:   answers,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &model.RegisterResult{BallotEntry: entry}, nil
+	case errors.Is(err, repository.ErrPaymentRequired):
+		order, err := s.placeOrder(ctx, eventID, &model.Order{
+			UserEmail:  req.UserEmail,
+			Name:       req.Name,
+			InviteCode: normalizeInviteCode(req.InviteCode),
+			SeatID:     normalizeSeatID(req.SeatID),
+			Answers:    answers,
+		}, normalizeAccessCode(req.AccessCode))
+		if err != nil {
+			return nil, err
+		}
+		return &model.RegisterResult{Order: order}, nil
+	case err != nil:
 		// Surface domain errors directly so handlers can set correct HTTP status.
 		if errors.Is(err, repository.ErrNotFound) ||
 			errors.Is(err, repository.ErrEventFull) ||
-			errors.Is(err, repository.ErrAlreadyRegistered) {
+			errors.Is(err, repository.ErrAlreadyRegistered) ||
+			errors.Is(err, repository.ErrInviteRequired) ||
+			errors.Is(err, repository.ErrInvalidInvite) ||
+			errors.Is(err, repository.ErrNotOnSale) ||
+			errors.Is(err, repository.ErrAccessCodeRequired) ||
+			isSeatError(err) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("register for event: %w", err)
 	}
-	return reg, nil
+	return &model.RegisterResult{Registration: reg}, nil
 }
 
-// ListRegistrations returns all registrations for an event.
-func (s *EventService) ListRegistrations(ctx context.Context, eventID string) ([]model.Registration, error) {
+// RegisterGroup books several attendees for one event, all or nothing.
+// Emails are normalized like Register's and must be unique within the group,
+// and every attendee must pass the eligibility rules.
+func (s *EventService) RegisterGroup(ctx context.Context, eventID string, req model.GroupRegisterRequest) (*model.GroupRegistration, error) {
+	if len(req.Attendees) == 0 {
+		return nil, fmt.Errorf("attendees must list at least one person")
+	}
+	if len(req.Attendees) > maxGroupSizeLimit {
+		return nil, fmt.Errorf("a group can have at most %d attendees", maxGroupSizeLimit)
+	}
+	questions, err := s.questions(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(req.Attendees))
+	for i := range req.Attendees {
+		a := &req.Attendees[i]
+		a.Email = strings.TrimSpace(strings.ToLower(a.Email))
+		a.Name = strings.TrimSpace(a.Name)
+		a.SeatID = strings.TrimSpace(a.SeatID)
+		if !isValidEmail(a.Email) {
+			return nil, fmt.Errorf("attendee %d: email is not a valid email address", i+1)
+		}
+		if utf8.RuneCountInString(a.Name) > maxNameLength {
+			return nil, fmt.Errorf("attendee %d: name cannot exceed %d characters", i+1, maxNameLength)
+		}
+		if seen[a.Email] {
+			return nil, fmt.Errorf("attendee %d: %s appears more than once", i+1, a.Email)
+		}
+		seen[a.Email] = true
+		if a.Answers, err = validateAnswers(questions, a.Answers); err != nil {
+			return nil, fmt.Errorf("attendee %d: %w", i+1, err)
+		}
+		if err = s.checkEligibility(ctx, eventID, a.Email, a.Answers, nil); err != nil {
+			return nil, fmt.Errorf("attendee %d: %w", i+1, err)
+		}
+	}
+
+	group, err := s.registrations.BookGroup(ctx, eventID, req.Attendees,
+		normalizeInviteCode(req.InviteCode), normalizeAccessCode(req.AccessCode))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrInviteRequired) ||
+			errors.Is(err, repository.ErrInvalidInvite) ||
+			errors.Is(err, repository.ErrNotOnSale) ||
+			errors.Is(err, repository.ErrAccessCodeRequired) ||
+			errors.Is(err, repository.ErrEventFull) ||
+			errors.Is(err, repository.ErrAlreadyRegistered) ||
+			errors.Is(err, repository.ErrGroupTooLarge) ||
+			errors.Is(err, repository.ErrApprovalRequired) ||
+			errors.Is(err, repository.ErrBallotOpen) ||
+			errors.Is(err, repository.ErrPaymentRequired) ||
+			isSeatError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("register group: %w", err)
+	}
+	return group, nil
+}
+
+// maxBundleEvents caps how many events one bundle registration may cover.
+const maxBundleEvents = 20
+
+// RegisterBundle registers one email for several events, all or nothing.
+// Each event's eligibility rules count the other events of the bundle.
+func (s *EventService) RegisterBundle(ctx context.Context, req model.BundleRegisterRequest) ([]model.Registration, error) {
+	req.UserEmail = strings.TrimSpace(strings.ToLower(req.UserEmail))
+	if req.UserEmail == "" {
+		return nil, fmt.Errorf("user_email is required")
+	}
+	if !isValidEmail(req.UserEmail) {
+		return nil, fmt.Errorf("user_email is not a valid email address")
+	}
+	ids := make([]string, 0, len(req.EventIDs))
+	for _, id := range req.EventIDs {
+		if id = strings.TrimSpace(id); id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("event_ids must list at least one event")
+	}
+	if len(ids) > maxBundleEvents {
+		return nil, fmt.Errorf("a bundle can cover at most %d events", maxBundleEvents)
+	}
+	for id := range req.Answers {
+		if !slices.Contains(ids, id) {
+			return nil, fmt.Errorf("answers given for event %s, which is not in the bundle", id)
+		}
+	}
+	answers := make(map[string]model.Answers, len(ids))
+	for _, id := range ids {
+		questions, err := s.questions(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue // reported by BookBundle with the other conflicts
+		}
+		if err != nil {
+			return nil, err
+		}
+		if answers[id], err = validateAnswers(questions, req.Answers[id]); err != nil {
+			return nil, fmt.Errorf("event %s: %w", id, err)
+		}
+		if err = s.checkEligibility(ctx, id, req.UserEmail, answers[id], ids); err != nil {
+			return nil, fmt.Errorf("event %s: %w", id, err)
+		}
+	}
+
+	regs, err := s.registrations.BookBundle(ctx, ids, req.UserEmail, answers)
+	if err != nil {
+		if errors.Is(err, repository.ErrBundleRejected) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("register for bundle: %w", err)
+	}
+	return regs, nil
+}
+
+// ListRegistrations returns one page of an event's registrations, oldest
+// first, without their ticket codes.
+func (s *EventService) ListRegistrations(ctx context.Context, eventID string, page model.PageRequest) (*model.Page[model.Registration], error) {
+	limit, err := validatePageLimit(page.Limit)
+	if err != nil {
+		return nil, err
+	}
+	after, err := repository.DecodeCursor(page.Cursor, repository.RegistrationSort)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.events.GetByID(ctx, eventID); err != nil {
+		return nil, repository.ErrNotFound
+	}
+	regs, next, err := s.registrations.ListByEvent(ctx, eventID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	hideTicketCodes(regs)
+	return newPage(regs, next), nil
+}
+
+// ListAllRegistrations returns every registration of an event in one slice.
+// It backs the legacy array response and should not be used for new callers.
+func (s *EventService) ListAllRegistrations(ctx context.Context, eventID string) ([]model.Registration, error) {
 	if _, err := s.events.GetByID(ctx, eventID); err != nil {
 		return nil, repository.ErrNotFound
 	}
-	return s.registrations.ListByEvent(ctx, eventID)
+	regs, _, err := s.registrations.ListByEvent(ctx, eventID, nil, 0)
+	hideTicketCodes(regs)
+	return regs, err
+}
+
+// hideTicketCodes blanks the ticket codes of listed registrations.  Lists
+// are shown to anyone with the event's link, and a code authorizes a
+// transfer; only the holder's own booking response and emails carry it.
+// Check-in and cancellation take a registration id, which lists show, so
+// their responses drop the code too, and webhooks never carry it.
+func hideTicketCodes(regs []model.Registration) {
+	for i := range regs {
+		regs[i].TicketCode = ""
+	}
+}
+
+// Radius search bounds, in kilometres.  The maximum is half the Earth's
+// circumference, which covers every point.
+const (
+	defaultRadiusKM = 25
+	maxRadiusKM     = 20_038
+)
+
+// Page size bounds for cursor-paginated lists.
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// validatePageLimit applies the default page size and checks the bounds.
+func validatePageLimit(limit int) (int, error) {
+	if limit == 0 {
+		return defaultPageLimit, nil
+	}
+	if limit < 0 || limit > maxPageLimit {
+		return 0, fmt.Errorf("%w: limit must be between 1 and %d", repository.ErrInvalidQuery, maxPageLimit)
+	}
+	return limit, nil
+}
+
+// newPage wraps a page of items and the cursor of the next one.
+func newPage[T any](items []T, next *repository.Cursor) *model.Page[T] {
+	page := &model.Page[T]{Items: items}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	if next != nil {
+		page.NextCursor = next.Encode()
+	}
+	return page
+}
+
+// Tag limits.
+const (
+	maxTagLength    = 32
+	maxTagsPerEvent = 10
+)
+
+// normalizeTags lower-cases tags, trims them and collapses inner whitespace,
+// so "Go" and "go " are one tag, then de-duplicates and sorts them.  The
+// result is never nil.
+func normalizeTags(tags []string) ([]string, error) {
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.Join(strings.Fields(t), " "))
+		if t == "" {
+			return nil, fmt.Errorf("tags cannot be empty")
+		}
+		if utf8.RuneCountInString(t) > maxTagLength {
+			return nil, fmt.Errorf("tag %q exceeds %d characters", t, maxTagLength)
+		}
+		out = append(out, t)
+	}
+	slices.Sort(out)
+	out = slices.Compact(out)
+	if len(out) > maxTagsPerEvent {
+		return nil, fmt.Errorf("at most %d tags are allowed", maxTagsPerEvent)
+	}
+	return out, nil
+}
+
+// validateSchedule checks the optional start/end pair and normalises it to UTC.
+func validateSchedule(startsAt, endsAt *time.Time) error {
+	if endsAt != nil && startsAt == nil {
+		return fmt.Errorf("ends_at requires starts_at")
+	}
+	if startsAt != nil {
+		*startsAt = startsAt.UTC()
+	}
+	if endsAt != nil {
+		*endsAt = endsAt.UTC()
+		if !endsAt.After(*startsAt) {
+			return fmt.Errorf("ends_at must be after starts_at")
+		}
+	}
+	return nil
+}
+
+// CancelRegistration releases a registration's seat.  A paid
+// registration's refund is recorded with the cancellation and attempted at
+// once; if the provider declines it, it stays pending and ProcessRefunds
+// retries it, so the cancellation stands either way.
+func (s *EventService) CancelRegistration(ctx context.Context, eventID, registrationID string) (*model.Registration, *model.Refund, error) {
+	if eventID == "" || registrationID == "" {
+		return nil, nil, fmt.Errorf("event id and registration id are required")
+	}
+	reg, refund, err := s.registrations.Cancel(ctx, eventID, registrationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("cancel registration: %w", err)
+	}
+	reg.TicketCode = ""
+	if refund != nil {
+		if issued, err := s.issueRefund(ctx, refund.ID); err != nil {
+			log.Printf("refund %s: %v", refund.ID, err)
+		} else if issued != nil {
+			refund = issued
+		}
+	}
+	return reg, refund, nil
+}
+
+// CheckIn records that an attendee arrived at the event.
+func (s *EventService) CheckIn(ctx context.Context, eventID, registrationID string) (*model.Registration, error) {
+	if eventID == "" || registrationID == "" {
+		return nil, fmt.Errorf("event id and registration id are required")
+	}
+	reg, err := s.registrations.CheckIn(ctx, eventID, registrationID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("check in: %w", err)
+	}
+	reg.TicketCode = ""
+	return reg, nil
+}
+
+// reminderBatch bounds how many reminders one QueueReminders pass writes in
+// a single statement.
+const reminderBatch = 500
+
+// QueueReminders queues reminder emails for every registration whose event
+// starts within lead, returning how many were queued.  It is run as a
+// recurring background job.
+func (s *EventService) QueueReminders(ctx context.Context, lead time.Duration) (int, error) {
+	total := 0
+	for {
+		n, err := s.registrations.QueueDueReminders(ctx, lead, reminderBatch)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < reminderBatch {
+			return total, nil
+		}
+	}
 }
 
 // isValidEmail does a basic structural check (no external deps).
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// SessionService manages event sessions and attendees' session picks.
+type SessionService struct {
+	sessions *repository.SessionRepository
+}
+
+// NewSessionService constructs a SessionService.
+func NewSessionService(sessions *repository.SessionRepository) *SessionService {
+	return &SessionService{sessions: sessions}
+}
+
+// CreateSession validates and adds a session to an event.
+func (s *SessionService) CreateSession(ctx context.Context, eventID string, req model.CreateSessionRequest) (*model.Session, error) {
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		return nil, fmt.Errorf("session title is required")
+	}
+	if req.StartsAt == nil || req.EndsAt == nil {
+		return nil, fmt.Errorf("starts_at and ends_at are required")
+	}
+	if err := validateSchedule(req.StartsAt, req.EndsAt); err != nil {
+		return nil, err
+	}
+	if req.Capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be a positive integer")
+	}
+	if req.Capacity > 100_000 {
+		return nil, fmt.Errorf("capacity cannot exceed 100,000")
+	}
+
+	session, err := s.sessions.Create(ctx, eventID, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrSessionOutsideEvent) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+	return session, nil
+}
+
+// ListSessions returns an event's sessions in start order.
+func (s *SessionService) ListSessions(ctx context.Context, eventID string) ([]model.Session, error) {
+	return s.sessions.ListByEvent(ctx, eventID)
+}
+
+// ListPicks returns the sessions an attendee picked, in start order.
+func (s *SessionService) ListPicks(ctx context.Context, eventID, registrationID string) ([]model.Session, error) {
+	return s.sessions.ListByRegistration(ctx, eventID, registrationID)
+}
+
+// PickSession books a session seat for a registered attendee.
+func (s *SessionService) PickSession(ctx context.Context, eventID, registrationID string, req model.PickSessionRequest) (*model.Session, error) {
+	req.SessionID = strings.TrimSpace(req.SessionID)
+	if req.SessionID == "" {
+		return nil, fmt.Errorf("session_id is required")
+	}
+
+	session, err := s.sessions.Pick(ctx, eventID, registrationID, req.SessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrSessionFull) ||
+			errors.Is(err, repository.ErrSessionAlreadyPicked) ||
+			errors.Is(err, repository.ErrSessionConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("pick session: %w", err)
+	}
+	return session, nil
+}
+
+// DropSession removes an attendee's session pick and releases the seat.
+func (s *SessionService) DropSession(ctx context.Context, eventID, registrationID, sessionID string) error {
+	err := s.sessions.Drop(ctx, eventID, registrationID, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("drop session: %w", err)
+	}
+	return nil
+}
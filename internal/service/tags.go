@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// TagService exposes the event tag catalogue.
+type TagService struct {
+	tags *repository.TagRepository
+}
+
+// NewTagService constructs a TagService.
+func NewTagService(tags *repository.TagRepository) *TagService {
+	return &TagService{tags: tags}
+}
+
+// ListTags returns every tag in use with its event count.
+func (s *TagService) ListTags(ctx context.Context) ([]model.Tag, error) {
+	return s.tags.List(ctx)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/notify"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// TemplateService manages per-event notification templates.
+type TemplateService struct {
+	events    *repository.EventRepository
+	templates *repository.TemplateRepository
+}
+
+// NewTemplateService constructs a TemplateService with its dependencies.
+func NewTemplateService(
+	events *repository.EventRepository,
+	templates *repository.TemplateRepository,
+) *TemplateService {
+	return &TemplateService{events: events, templates: templates}
+}
+
+// ListTemplates returns the effective template of every kind for an event:
+// its override where one exists, otherwise the default.
+func (s *TemplateService) ListTemplates(ctx context.Context, eventID string) ([]model.MessageTemplate, error) {
+	if _, err := s.events.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	overrides, err := s.templates.ListByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.MessageTemplate, 0, len(model.TemplateKinds))
+	for _, kind := range model.TemplateKinds {
+		i := slices.IndexFunc(overrides, func(t model.MessageTemplate) bool { return t.Kind == kind })
+		if i >= 0 {
+			result = append(result, overrides[i])
+			continue
+		}
+		def, _ := notify.DefaultTemplate(kind)
+		result = append(result, def)
+	}
+	return result, nil
+}
+
+// SaveTemplate validates and stores an override. The template is parsed and
+// rendered against a sample registration first, so a broken template is
+// rejected here instead of failing every send later.
+func (s *TemplateService) SaveTemplate(ctx context.Context, eventID, kind string, req model.SaveTemplateRequest) (*model.MessageTemplate, error) {
+	if err := validateTemplateKind(kind); err != nil {
+		return nil, err
+	}
+	event, err := s.events.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl := &model.MessageTemplate{
+		EventID:  eventID,
+		Kind:     kind,
+		Subject:  req.Subject,
+		TextBody: req.TextBody,
+		HTMLBody: req.HTMLBody,
+	}
+	if err := notify.Validate(*tpl, *event); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	if err := s.templates.Upsert(ctx, tpl); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+// ResetTemplate deletes an override so the event falls back to the default.
+func (s *TemplateService) ResetTemplate(ctx context.Context, eventID, kind string) error {
+	if err := validateTemplateKind(kind); err != nil {
+		return err
+	}
+	if _, err := s.events.GetByID(ctx, eventID); err != nil {
+		return err
+	}
+	err := s.templates.Delete(ctx, eventID, kind)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil // already using the default
+	}
+	return err
+}
+
+// PreviewTemplate renders a template for a sample registration to the event.
+// When draft is nil the event's effective template is rendered; otherwise the
+// draft is, without saving it.
+func (s *TemplateService) PreviewTemplate(ctx context.Context, eventID, kind string, draft *model.SaveTemplateRequest) (*model.TemplatePreview, error) {
+	if err := validateTemplateKind(kind); err != nil {
+		return nil, err
+	}
+	event, err := s.events.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tpl model.MessageTemplate
+	if draft != nil {
+		tpl = model.MessageTemplate{Kind: kind, Subject: draft.Subject, TextBody: draft.TextBody, HTMLBody: draft.HTMLBody}
+		if err := notify.Validate(tpl, *event); err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+	} else {
+		tpl, err = notify.EffectiveTemplate(ctx, s.templates, eventID, kind)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msg, err := notify.Compose(tpl, notify.SampleData(*event))
+	if err != nil {
+		return nil, fmt.Errorf("render template: %w", err)
+	}
+	preview := &model.TemplatePreview{
+		Subject:     msg.Subject,
+		TextBody:    msg.Body,
+		HTMLBody:    msg.HTMLBody,
+		Attachments: []string{},
+	}
+	for _, a := range msg.Attachments {
+		preview.Attachments = append(preview.Attachments, a.Filename)
+	}
+	return preview, nil
+}
+
+func validateTemplateKind(kind string) error {
+	if !slices.Contains(model.TemplateKinds, kind) {
+		return fmt.Errorf("unknown template kind %q (want one of %v)", kind, model.TemplateKinds)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// TransferTicket hands a registration to another attendee, authorized by
+// its current ticket code.  The new attendee is validated like a
+// registrant: the email is normalized, the answers checked against the
+// form, and the event's eligibility rules applied.  It returns the recorded
+// transfer; the new ticket code is only sent to the new holder.
+func (s *EventService) TransferTicket(ctx context.Context, eventID, registrationID string, req model.TransferRequest) (*model.TicketTransfer, error) {
+	if eventID == "" || registrationID == "" {
+		return nil, fmt.Errorf("event id and registration id are required")
+	}
+	req.TicketCode = strings.TrimSpace(req.TicketCode)
+	if req.TicketCode == "" {
+		return nil, fmt.Errorf("ticket_code is required")
+	}
+	req.UserEmail = strings.TrimSpace(strings.ToLower(req.UserEmail))
+	if req.UserEmail == "" {
+		return nil, fmt.Errorf("user_email is required")
+	}
+	if !isValidEmail(req.UserEmail) {
+		return nil, fmt.Errorf("user_email is not a valid email address")
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if utf8.RuneCountInString(req.Name) > maxNameLength {
+		return nil, fmt.Errorf("name cannot exceed %d characters", maxNameLength)
+	}
+	questions, err := s.questions(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := validateAnswers(questions, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEligibility(ctx, eventID, req.UserEmail, answers, nil); err != nil {
+		return nil, err
+	}
+
+	_, transfer, err := s.registrations.Transfer(ctx, eventID, registrationID, req.TicketCode, model.Registration{
+		UserEmail: req.UserEmail,
+		Name:      req.Name,
+		Answers:   answers,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) ||
+			errors.Is(err, repository.ErrTransfersDisabled) ||
+			errors.Is(err, repository.ErrInvalidTicket) ||
+			errors.Is(err, repository.ErrTicketUsed) ||
+			errors.Is(err, repository.ErrAlreadyRegistered) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("transfer ticket: %w", err)
+	}
+	return transfer, nil
+}
+
+// ListTransfers returns an event's ticket transfers, oldest first.
+func (s *EventService) ListTransfers(ctx context.Context, eventID string) ([]model.TicketTransfer, error) {
+	transfers, err := s.registrations.Transfers(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("list transfers: %w", err)
+	}
+	return transfers, nil
+}
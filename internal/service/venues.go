@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// VenueService manages venues.
+type VenueService struct {
+	venues *repository.VenueRepository
+}
+
+// NewVenueService constructs a VenueService.
+func NewVenueService(venues *repository.VenueRepository) *VenueService {
+	return &VenueService{venues: venues}
+}
+
+// CreateVenue validates and stores a venue.
+func (s *VenueService) CreateVenue(ctx context.Context, req model.CreateVenueRequest) (*model.Venue, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Address = strings.TrimSpace(req.Address)
+	if req.Name == "" {
+		return nil, fmt.Errorf("venue name is required")
+	}
+	if req.Latitude == nil || req.Longitude == nil {
+		return nil, fmt.Errorf("latitude and longitude are required")
+	}
+	if *req.Latitude < -90 || *req.Latitude > 90 {
+		return nil, fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if *req.Longitude < -180 || *req.Longitude > 180 {
+		return nil, fmt.Errorf("longitude must be between -180 and 180")
+	}
+	if req.MaxCapacity <= 0 {
+		return nil, fmt.Errorf("max_capacity must be a positive integer")
+	}
+	return s.venues.Create(ctx, req)
+}
+
+// GetVenue returns a single venue.
+func (s *VenueService) GetVenue(ctx context.Context, id string) (*model.Venue, error) {
+	return s.venues.Get(ctx, id)
+}
+
+// ListVenues returns all venues.
+func (s *VenueService) ListVenues(ctx context.Context) ([]model.Venue, error) {
+	return s.venues.List(ctx)
+}
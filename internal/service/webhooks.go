@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/model"
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// deliveryLogLimit caps how many deliveries the log endpoint returns.
+const deliveryLogLimit = 100
+
+// WebhookService manages organizer webhook endpoints and their delivery log.
+type WebhookService struct {
+	events   *repository.EventRepository
+	webhooks *repository.WebhookRepository
+}
+
+// NewWebhookService constructs a WebhookService with its dependencies.
+func NewWebhookService(
+	events *repository.EventRepository,
+	webhooks *repository.WebhookRepository,
+) *WebhookService {
+	return &WebhookService{events: events, webhooks: webhooks}
+}
+
+// CreateWebhook validates and registers an endpoint. The returned endpoint
+// carries its signing secret; this is the only time the secret is shown.
+func (s *WebhookService) CreateWebhook(ctx context.Context, req model.CreateWebhookRequest) (*model.WebhookEndpoint, error) {
+	u, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("url must be an absolute http(s) URL")
+	}
+	if len(req.EventTypes) == 0 {
+		return nil, fmt.Errorf("event_types must list at least one of %v", model.WebhookEventTypes)
+	}
+	for _, t := range req.EventTypes {
+		if !slices.Contains(model.WebhookEventTypes, t) {
+			return nil, fmt.Errorf("unknown event type %q (want one of %v)", t, model.WebhookEventTypes)
+		}
+	}
+	if req.EventID != nil {
+		if _, err := s.events.GetByID(ctx, *req.EventID); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	types := slices.Clone(req.EventTypes)
+	slices.Sort(types)
+
+	endpoint := &model.WebhookEndpoint{
+		EventID:    req.EventID,
+		URL:        u.String(),
+		Secret:     secret,
+		EventTypes: slices.Compact(types),
+	}
+	if err := s.webhooks.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// ListWebhooks returns endpoints, optionally limited to one event. Secrets
+// are stripped.
+func (s *WebhookService) ListWebhooks(ctx context.Context, eventID string) ([]model.WebhookEndpoint, error) {
+	endpoints, err := s.webhooks.ListEndpoints(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range endpoints {
+		endpoints[i].Secret = ""
+	}
+	return endpoints, nil
+}
+
+// DeleteWebhook removes an endpoint and its delivery log.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	return s.webhooks.DeleteEndpoint(ctx, id)
+}
+
+// ListDeliveries returns the recent delivery log of an endpoint.
+func (s *WebhookService) ListDeliveries(ctx context.Context, endpointID string) ([]model.WebhookDelivery, error) {
+	if _, err := s.webhooks.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+	return s.webhooks.ListDeliveries(ctx, endpointID, deliveryLogLimit)
+}
+
+// Redeliver queues a delivery again, typically one that was dead-lettered.
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID string) (*model.WebhookDelivery, error) {
+	d, err := s.webhooks.Redeliver(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("redeliver: %w", err)
+	}
+	return d, nil
+}
+
+// newWebhookSecret returns a random 256-bit signing secret.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,175 @@
+// Package webhook delivers organizer webhooks: it signs each payload with the
+// endpoint's secret and retries failed deliveries with exponential backoff
+// until they succeed or are dead-lettered.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Shivanand-hulikatti/event-reg-and-ticketing/internal/repository"
+)
+
+// Dispatcher tuning. Backoff doubles from retryBase up to retryCap; after
+// maxAttempts failed deliveries a delivery is moved to the dead state.
+// Deliveries are claimed one at a time, each just before it is POSTed, and
+// claimLease must outlast requestTimeout so that no other dispatcher can
+// claim a delivery while it is still being sent.
+const (
+	pollInterval   = 2 * time.Second
+	batchSize      = 20
+	claimLease     = time.Minute
+	requestTimeout = 10 * time.Second
+	retryBase      = 30 * time.Second
+	retryCap       = 6 * time.Hour
+	maxAttempts    = 10
+)
+
+// Request headers sent with every delivery.
+const (
+	HeaderID        = "Webhook-Id"
+	HeaderTimestamp = "Webhook-Timestamp"
+	HeaderSignature = "Webhook-Signature"
+)
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>" under secret.
+//
+// Including the timestamp in the signed content lets receivers reject
+// replayed requests: verify the signature, then reject timestamps older than
+// a few minutes.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher polls the delivery queue and POSTs due deliveries.
+type Dispatcher struct {
+	webhooks *repository.WebhookRepository
+	client   *http.Client
+}
+
+// NewDispatcher constructs a Dispatcher.
+func NewDispatcher(webhooks *repository.WebhookRepository) *Dispatcher {
+	return &Dispatcher{
+		webhooks: webhooks,
+		client:   &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Run polls until ctx is cancelled, finishing any batch in flight first.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := d.dispatchBatch(context.WithoutCancel(ctx))
+			if err != nil {
+				log.Printf("webhooks: %v", err)
+				break
+			}
+			if n < batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchBatch claims and POSTs up to batchSize deliveries, returning how
+// many were claimed.  Each delivery is claimed on its own right before it
+// is sent, so its lease covers only its own request, however slow the
+// endpoints before it were.
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	claimed := 0
+	for claimed < batchSize {
+		deliveries, err := d.webhooks.ClaimDeliveries(ctx, 1, claimLease)
+		if err != nil {
+			return claimed, err
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+		claimed++
+		c := deliveries[0]
+
+		statusCode, sendErr := d.post(ctx, c)
+		if sendErr == nil {
+			if err := d.webhooks.MarkDelivered(ctx, c.ID, statusCode); err != nil {
+				log.Printf("webhooks: %v", err)
+			}
+			continue
+		}
+
+		var code *int
+		if statusCode != 0 {
+			code = &statusCode
+		}
+		var retryAt *time.Time
+		if c.Attempts < maxAttempts {
+			t := time.Now().UTC().Add(backoff(c.Attempts))
+			retryAt = &t
+		}
+		log.Printf("webhooks: deliver %s to %s (attempt %d): %v", c.EventType, c.URL, c.Attempts, sendErr)
+		if err := d.webhooks.MarkFailed(ctx, c.ID, code, sendErr, retryAt); err != nil {
+			log.Printf("webhooks: %v", err)
+		}
+	}
+	return claimed, nil
+}
+
+// post sends one signed delivery. It returns the response status code (0 if
+// no response was received) and an error for anything but a 2xx.
+func (d *Dispatcher) post(ctx context.Context, c repository.ClaimedDelivery) (int, error) {
+	ts := time.Now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(c.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EventBooking-Webhooks/1.0")
+	req.Header.Set(HeaderID, c.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, "v1="+Sign(c.Secret, ts, c.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before retry number attempt (1-based).
+func backoff(attempt int) time.Duration {
+	d := retryBase
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= retryCap {
+			return retryCap
+		}
+	}
+	return d
+}
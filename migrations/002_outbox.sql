@@ -0,0 +1,35 @@
+-- migrations/002_outbox.sql
+-- Transactional outbox for attendee notifications.
+-- Run with: psql -U postgres -d eventbooking -f migrations/002_outbox.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- OUTBOX
+-- ─────────────────────────────────────────────────────────────────────────────
+-- A row is inserted in the SAME transaction as the state change it describes
+-- (e.g. the booking in RegistrationRepository.Book).  If the booking rolls
+-- back, the message disappears with it; if it commits, the message is durable
+-- and the dispatcher will deliver it even if the process crashes right after
+-- COMMIT.
+--
+-- The dispatcher claims due rows with FOR UPDATE SKIP LOCKED and pushes
+-- next_attempt_at into the future as a lease, so several API instances can
+-- run dispatchers without sending the same message twice concurrently.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS outbox (
+    id              TEXT        PRIMARY KEY,
+    kind            TEXT        NOT NULL,
+    recipient       TEXT        NOT NULL,
+    payload         JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    status          TEXT        NOT NULL DEFAULT 'pending'
+                                CHECK (status IN ('pending', 'sent', 'dead')),
+    attempts        INTEGER     NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    last_error      TEXT        NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at         TIMESTAMPTZ
+);
+
+-- Only pending rows are ever polled, so keep the index small.
+CREATE INDEX IF NOT EXISTS idx_outbox_due
+    ON outbox(next_attempt_at)
+    WHERE status = 'pending';
@@ -0,0 +1,35 @@
+-- migrations/003_message_templates.sql
+-- Event schedule and per-event notification templates.
+-- Run with: psql -U postgres -d eventbooking -f migrations/003_message_templates.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- EVENT SCHEDULE
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Optional start/end times.  Needed for calendar (.ics) attachments and for
+-- reminders; events created before this migration simply have no schedule.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE events ADD COLUMN IF NOT EXISTS starts_at TIMESTAMPTZ;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS ends_at   TIMESTAMPTZ;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS valid_schedule;
+ALTER TABLE events ADD CONSTRAINT valid_schedule
+    CHECK (ends_at IS NULL OR (starts_at IS NOT NULL AND ends_at > starts_at));
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- MESSAGE TEMPLATES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Per-event overrides of the built-in default templates (internal/notify).
+-- Templates are parsed and test-rendered by the service before they are
+-- saved, so a row in this table is always renderable.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS message_templates (
+    event_id   TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    kind       TEXT        NOT NULL
+                           CHECK (kind IN ('confirmation', 'reminder', 'cancellation')),
+    subject    TEXT        NOT NULL,
+    text_body  TEXT        NOT NULL,
+    html_body  TEXT        NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (event_id, kind)
+);
@@ -0,0 +1,50 @@
+-- migrations/004_webhooks.sql
+-- Organizer webhooks with a signed, retried delivery queue.
+-- Run with: psql -U postgres -d eventbooking -f migrations/004_webhooks.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- WEBHOOK ENDPOINTS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- event_id NULL means the endpoint is account-wide and receives notifications
+-- for every event; otherwise it only receives those for that one event.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id          TEXT        PRIMARY KEY,
+    event_id    TEXT        REFERENCES events(id) ON DELETE CASCADE,
+    url         TEXT        NOT NULL CHECK (url ~ '^https?://'),
+    secret      TEXT        NOT NULL,
+    event_types TEXT[]      NOT NULL CHECK (cardinality(event_types) > 0),
+    active      BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_event_id ON webhook_endpoints(event_id);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- WEBHOOK DELIVERIES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- One row per (notification, endpoint), inserted in the same transaction as
+-- the change being announced.  Doubles as the delivery log: failed rows keep
+-- the last status code and error, and rows that exhaust their retries are
+-- parked as 'dead' until someone redelivers them.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               TEXT        PRIMARY KEY,
+    endpoint_id      TEXT        NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    event_type       TEXT        NOT NULL,
+    payload          JSONB       NOT NULL,
+    status           TEXT        NOT NULL DEFAULT 'pending'
+                                 CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts         INTEGER     NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    last_status_code INTEGER,
+    last_error       TEXT        NOT NULL DEFAULT '',
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint
+    ON webhook_deliveries(endpoint_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+    ON webhook_deliveries(next_attempt_at)
+    WHERE status = 'pending';
@@ -0,0 +1,49 @@
+-- migrations/005_jobs.sql
+-- Background job queue and reminder bookkeeping.
+-- Run with: psql -U postgres -d eventbooking -f migrations/005_jobs.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- JOBS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Workers claim one job at a time with FOR UPDATE SKIP LOCKED and hold a lease
+-- (locked_until).  A job whose worker died stays 'running' with an expired
+-- lease and is picked up again by the next claim.
+--
+-- unique_key de-duplicates enqueues: the recurring scheduler on every API
+-- instance enqueues "reminders@2026-01-01T10:00Z" and only the first INSERT
+-- wins.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS jobs (
+    id           TEXT        PRIMARY KEY,
+    kind         TEXT        NOT NULL,
+    payload      JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    status       TEXT        NOT NULL DEFAULT 'queued'
+                             CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    attempts     INTEGER     NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    max_attempts INTEGER     NOT NULL DEFAULT 5 CHECK (max_attempts > 0),
+    unique_key   TEXT        UNIQUE,
+    run_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMPTZ,
+    last_error   TEXT        NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_queued
+    ON jobs(run_at)
+    WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS idx_jobs_running
+    ON jobs(locked_until)
+    WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_jobs_status_created
+    ON jobs(status, created_at DESC);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- REMINDERS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Set when the reminder email for a registration is written to the outbox,
+-- so the recurring reminder job never queues the same reminder twice.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS reminder_queued_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_events_starts_at ON events(starts_at) WHERE starts_at IS NOT NULL;
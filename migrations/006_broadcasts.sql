@@ -0,0 +1,67 @@
+-- migrations/006_broadcasts.sql
+-- Organizer broadcast messages, per-recipient delivery status and
+-- unsubscribes.
+-- Run with: psql -U postgres -d eventbooking -f migrations/006_broadcasts.sql
+
+-- Set when an attendee is checked in at the door.
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMPTZ;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- BROADCAST MESSAGES
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS broadcast_messages (
+    id           TEXT        PRIMARY KEY,
+    event_id     TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    subject      TEXT        NOT NULL CHECK (char_length(subject) BETWEEN 1 AND 200),
+    body         TEXT        NOT NULL CHECK (char_length(body) > 0),
+    audience     TEXT        NOT NULL,
+    status       TEXT        NOT NULL DEFAULT 'sending'
+                             CHECK (status IN ('sending', 'completed')),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcast_messages_event
+    ON broadcast_messages(event_id, created_at DESC);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- BROADCAST RECIPIENTS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- The recipient list is frozen when the message is composed.  Each row tracks
+-- its own delivery and carries the random token behind its unsubscribe link.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS broadcast_recipients (
+    message_id        TEXT        NOT NULL REFERENCES broadcast_messages(id) ON DELETE CASCADE,
+    email             TEXT        NOT NULL,
+    status            TEXT        NOT NULL DEFAULT 'pending'
+                                  CHECK (status IN ('pending', 'sent', 'failed', 'unsubscribed')),
+    attempts          INTEGER     NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    last_error        TEXT        NOT NULL DEFAULT '',
+    unsubscribe_token TEXT        NOT NULL UNIQUE,
+    sent_at           TIMESTAMPTZ,
+
+    PRIMARY KEY (message_id, email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcast_recipients_pending
+    ON broadcast_recipients(message_id)
+    WHERE status = 'pending';
+
+-- A send job leases the pending rows of its batch until locked_until, so a
+-- second job for the same message skips them instead of mailing them again.
+ALTER TABLE broadcast_recipients ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- UNSUBSCRIBES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Opt-outs are per event: an attendee who unsubscribes stops receiving that
+-- event's broadcasts.  Transactional mail (confirmations, reminders) is not
+-- affected.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS unsubscribes (
+    event_id   TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    email      TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (event_id, email)
+);
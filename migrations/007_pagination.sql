@@ -0,0 +1,16 @@
+-- migrations/007_pagination.sql
+-- Keyset indexes for cursor pagination on (created_at, id).
+-- Run with: psql -U postgres -d eventbooking -f migrations/007_pagination.sql
+
+-- GET /events walks events newest first; the id column breaks ties so the
+-- row comparison (created_at, id) < ($1, $2) is a single index range scan.
+CREATE INDEX IF NOT EXISTS idx_events_created_id
+    ON events(created_at DESC, id DESC);
+
+-- GET /events/{id}/registrations walks one event's registrations oldest first.
+CREATE INDEX IF NOT EXISTS idx_registrations_event_created_id
+    ON registrations(event_id, created_at, id);
+
+-- Both are strict supersets of the original single-column indexes.
+DROP INDEX IF EXISTS idx_events_created_at;
+DROP INDEX IF EXISTS idx_registrations_event_id;
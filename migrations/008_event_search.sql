@@ -0,0 +1,18 @@
+-- migrations/008_event_search.sql
+-- Full-text search over event names and descriptions.
+-- Run with: psql -U postgres -d eventbooking -f migrations/008_event_search.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- SEARCH VECTOR
+-- ─────────────────────────────────────────────────────────────────────────────
+-- A stored generated column keeps the vector in step with every INSERT and
+-- UPDATE without triggers.  Name matches are weighted above description
+-- matches (A > B), which ts_rank uses when sorting by relevance.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE events ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_events_search ON events USING GIN (search_vector);
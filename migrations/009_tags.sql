@@ -0,0 +1,27 @@
+-- migrations/009_tags.sql
+-- Event tags (many-to-many).
+-- Run with: psql -U postgres -d eventbooking -f migrations/009_tags.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- TAGS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Names are stored normalized (trimmed, lower-case, inner whitespace collapsed
+-- to one space), so "Go" and "go " are the same tag.  The service normalizes;
+-- the CHECK only guards the basics.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS tags (
+    name       TEXT        PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT normalized_tag CHECK (name = btrim(name) AND char_length(name) BETWEEN 1 AND 32)
+);
+
+CREATE TABLE IF NOT EXISTS event_tags (
+    event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    tag      TEXT NOT NULL REFERENCES tags(name),
+
+    PRIMARY KEY (event_id, tag)
+);
+
+-- Tag filters and GET /tags counts look events up by tag.
+CREATE INDEX IF NOT EXISTS idx_event_tags_tag ON event_tags(tag, event_id);
@@ -0,0 +1,25 @@
+-- migrations/010_venues.sql
+-- Venues with coordinates and a maximum capacity; events reference them.
+-- Run with: psql -U postgres -d eventbooking -f migrations/010_venues.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- VENUES
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS venues (
+    id           TEXT             PRIMARY KEY,
+    name         TEXT             NOT NULL CHECK (char_length(name) BETWEEN 1 AND 200),
+    address      TEXT             NOT NULL DEFAULT '',
+    latitude     DOUBLE PRECISION NOT NULL CHECK (latitude  BETWEEN -90  AND 90),
+    longitude    DOUBLE PRECISION NOT NULL CHECK (longitude BETWEEN -180 AND 180),
+    max_capacity INTEGER          NOT NULL CHECK (max_capacity > 0),
+    created_at   TIMESTAMPTZ      NOT NULL DEFAULT NOW()
+);
+
+-- Radius searches first narrow venues to a latitude band around the search
+-- point (cheap, indexable), then apply the exact haversine distance.
+CREATE INDEX IF NOT EXISTS idx_venues_latitude ON venues(latitude);
+
+-- An event's venue cannot be deleted while events still reference it.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS venue_id TEXT REFERENCES venues(id);
+
+CREATE INDEX IF NOT EXISTS idx_events_venue_id ON events(venue_id);
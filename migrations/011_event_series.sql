@@ -0,0 +1,34 @@
+-- migrations/011_event_series.sql
+-- Recurring event series; each occurrence is an ordinary event row.
+-- Run with: psql -U postgres -d eventbooking -f migrations/011_event_series.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- EVENT SERIES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- The series row is the template for occurrences still to be generated: the
+-- fields an "all future occurrences" edit last set, the recurrence rule and
+-- its first start.  starts_at carries the wall-clock time of day in timezone;
+-- ends_at (optional) fixes every occurrence's duration.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS event_series (
+    id          TEXT        PRIMARY KEY,
+    name        TEXT        NOT NULL CHECK (char_length(name) BETWEEN 1 AND 200),
+    description TEXT        NOT NULL DEFAULT '',
+    capacity    INTEGER     NOT NULL CHECK (capacity > 0),
+    venue_id    TEXT        REFERENCES venues(id),
+    tags        TEXT[]      NOT NULL DEFAULT '{}',
+    rrule       TEXT        NOT NULL,
+    timezone    TEXT        NOT NULL DEFAULT 'UTC',
+    starts_at   TIMESTAMPTZ NOT NULL,
+    ends_at     TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT series_schedule CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+-- Deleting a series is not supported; occurrences are deleted one by one (or
+-- "this and future") and only while they have no registrations.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS series_id TEXT REFERENCES event_series(id);
+
+CREATE INDEX IF NOT EXISTS idx_events_series_starts ON events(series_id, starts_at)
+    WHERE series_id IS NOT NULL;
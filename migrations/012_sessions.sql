@@ -0,0 +1,40 @@
+-- migrations/012_sessions.sql
+-- Sessions inside an event, each with its own time slot and capacity, and
+-- the sessions each registered attendee picked.
+-- Run with: psql -U postgres -d eventbooking -f migrations/012_sessions.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- SESSIONS
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS sessions (
+    id           TEXT        PRIMARY KEY,
+    event_id     TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    title        TEXT        NOT NULL CHECK (char_length(title) BETWEEN 1 AND 200),
+    starts_at    TIMESTAMPTZ NOT NULL,
+    ends_at      TIMESTAMPTZ NOT NULL,
+    capacity     INTEGER     NOT NULL CHECK (capacity > 0),
+    booked_count INTEGER     NOT NULL DEFAULT 0 CHECK (booked_count >= 0),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Same safety net as events.no_overbooking, per session.
+    CONSTRAINT session_no_overbooking CHECK (booked_count <= capacity),
+    CONSTRAINT session_schedule       CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_event_starts ON sessions(event_id, starts_at);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- SESSION PICKS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Cancelling a registration releases its picks (and their seats) in the same
+-- transaction before the registration row is deleted.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS session_registrations (
+    session_id      TEXT        NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    registration_id TEXT        NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (registration_id, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_registrations_session ON session_registrations(session_id);
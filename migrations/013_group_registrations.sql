@@ -0,0 +1,16 @@
+-- migrations/013_group_registrations.sql
+-- Group registrations: several attendees booked in one request.
+-- Run with: psql -U postgres -d eventbooking -f migrations/013_group_registrations.sql
+
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS max_group_size INTEGER NOT NULL DEFAULT 10
+        CHECK (max_group_size BETWEEN 1 AND 1000);
+
+-- Registrations booked together share a group_id; the attendee name is
+-- optional and empty for single registrations.
+ALTER TABLE registrations
+    ADD COLUMN IF NOT EXISTS attendee_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS group_id      TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_registrations_group_id ON registrations(group_id)
+    WHERE group_id IS NOT NULL;
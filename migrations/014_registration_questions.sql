@@ -0,0 +1,19 @@
+-- migrations/014_registration_questions.sql
+-- Organizer-defined registration questions and the answers given.
+-- Run with: psql -U postgres -d eventbooking -f migrations/014_registration_questions.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- QUESTIONS AND ANSWERS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- The form schema is a JSON array of questions ({id, label, type, required,
+-- options}) replaced as a whole; answers are a JSON object keyed by question
+-- id, validated against the schema by the service at registration time.
+-- Changing the schema later leaves earlier answers untouched.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS questions JSONB NOT NULL DEFAULT '[]'
+        CHECK (jsonb_typeof(questions) = 'array');
+
+ALTER TABLE registrations
+    ADD COLUMN IF NOT EXISTS answers JSONB NOT NULL DEFAULT '{}'
+        CHECK (jsonb_typeof(answers) = 'object');
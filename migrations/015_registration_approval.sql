@@ -0,0 +1,39 @@
+-- migrations/015_registration_approval.sql
+-- Approval mode: registering for a curated event files an application that
+-- an organizer approves or rejects.
+-- Run with: psql -U postgres -d eventbooking -f migrations/015_registration_approval.sql
+
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS requires_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- APPLICATIONS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- A pending application holds no seat.  Approval locks the event row, checks
+-- capacity and books the seat in the same transaction, then links the new
+-- registration.  Decided applications are kept as a record; an applicant may
+-- apply again after a rejection, but never holds two pending applications.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS applications (
+    id              TEXT        PRIMARY KEY,
+    event_id        TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_email      TEXT        NOT NULL,
+    attendee_name   TEXT        NOT NULL DEFAULT '',
+    answers         JSONB       NOT NULL DEFAULT '{}' CHECK (jsonb_typeof(answers) = 'object'),
+    status          TEXT        NOT NULL DEFAULT 'pending'
+                                CHECK (status IN ('pending', 'approved', 'rejected')),
+    registration_id TEXT        REFERENCES registrations(id) ON DELETE SET NULL,
+    decided_at      TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT application_decision CHECK ((status = 'pending') = (decided_at IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_applications_pending_email
+    ON applications(event_id, user_email) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_applications_event_created ON applications(event_id, created_at);
+
+-- Rejected applicants get their own notification template.
+ALTER TABLE message_templates DROP CONSTRAINT IF EXISTS message_templates_kind_check;
+ALTER TABLE message_templates ADD CONSTRAINT message_templates_kind_check
+    CHECK (kind IN ('confirmation', 'reminder', 'cancellation', 'rejection'));
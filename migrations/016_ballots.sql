@@ -0,0 +1,51 @@
+-- migrations/016_ballots.sql
+-- Ballot mode: entries during a window, then a seeded draw that books the
+-- winners and puts everyone else on an ordered waitlist.
+-- Run with: psql -U postgres -d eventbooking -f migrations/016_ballots.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- BALLOTS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- At most one ballot per event.  seed_hash (SHA-256 of seed) is published
+-- while entries are open; the seed itself only once drawn_at is set, so
+-- anyone can recompute the draw but nobody can predict it.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS ballots (
+    event_id   TEXT        PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    opens_at   TIMESTAMPTZ NOT NULL,
+    closes_at  TIMESTAMPTZ NOT NULL,
+    seed       TEXT        NOT NULL,
+    seed_hash  TEXT        NOT NULL,
+    drawn_at   TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT ballot_window CHECK (closes_at > opens_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ballots_due ON ballots(closes_at) WHERE drawn_at IS NULL;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- BALLOT ENTRIES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- outcome moves from 'entered' to 'won' or 'waitlisted' in the draw, and
+-- from 'waitlisted' to 'promoted' when a freed seat goes to the entry.
+-- draw_rank and ticket record the entry's place in the published draw.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS ballot_entries (
+    id              TEXT        PRIMARY KEY,
+    event_id        TEXT        NOT NULL REFERENCES ballots(event_id) ON DELETE CASCADE,
+    user_email      TEXT        NOT NULL,
+    attendee_name   TEXT        NOT NULL DEFAULT '',
+    answers         JSONB       NOT NULL DEFAULT '{}' CHECK (jsonb_typeof(answers) = 'object'),
+    outcome         TEXT        NOT NULL DEFAULT 'entered'
+                                CHECK (outcome IN ('entered', 'won', 'waitlisted', 'promoted')),
+    draw_rank       INTEGER,
+    ticket          TEXT,
+    registration_id TEXT        REFERENCES registrations(id) ON DELETE SET NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT one_entry_per_email UNIQUE (event_id, user_email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ballot_entries_waitlist ON ballot_entries(event_id, draw_rank)
+    WHERE outcome = 'waitlisted';
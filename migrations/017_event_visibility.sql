@@ -0,0 +1,34 @@
+-- migrations/017_event_visibility.sql
+-- Event visibility (public, unlisted, invite-only) and invite codes.
+-- Run with: psql -U postgres -d eventbooking -f migrations/017_event_visibility.sql
+
+-- Only public events appear in listings, search and tag counts.  Unlisted
+-- and invite-only events are reached through their link.
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS visibility TEXT NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'unlisted', 'invite_only'));
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- INVITE CODES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Each booking with a code increments used_count in the same transaction
+-- as the seat; the CHECK is the safety net against over-redemption, as
+-- no_overbooking is for seats.  Cancelling the registration gives the use
+-- back.  Revoked codes are kept so existing registrations still show them.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS invite_codes (
+    code       TEXT        PRIMARY KEY,
+    event_id   TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    label      TEXT        NOT NULL DEFAULT '',
+    max_uses   INTEGER     NOT NULL DEFAULT 1 CHECK (max_uses > 0),
+    used_count INTEGER     NOT NULL DEFAULT 0 CHECK (used_count >= 0),
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT invite_not_overused CHECK (used_count <= max_uses)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invite_codes_event ON invite_codes(event_id, created_at);
+
+ALTER TABLE registrations
+    ADD COLUMN IF NOT EXISTS invite_code TEXT REFERENCES invite_codes(code) ON DELETE SET NULL;
@@ -0,0 +1,15 @@
+-- migrations/018_eligibility_rules.sql
+-- Per-event eligibility rules checked before a registration is booked.
+-- Run with: psql -U postgres -d eventbooking -f migrations/018_eligibility_rules.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- ELIGIBILITY RULES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- A JSON object ({allowed_domains, blocked_domains, limits, required_answers})
+-- replaced as a whole and evaluated by the service, like the questions.  The
+-- per-email limits count registrations across a series or a tag, so they
+-- lean on the existing (user_email) and event_tags indexes.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS eligibility JSONB NOT NULL DEFAULT '{}'
+        CHECK (jsonb_typeof(eligibility) = 'object');
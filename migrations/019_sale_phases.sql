@@ -0,0 +1,29 @@
+-- migrations/019_sale_phases.sql
+-- Access phases: presales with a code and general sale, each with an
+-- optional seat allocation carved out of the event's capacity.
+-- Run with: psql -U postgres -d eventbooking -f migrations/019_sale_phases.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- SALE PHASES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- A phase is open from starts_at until ends_at (NULL: until the event).  Its
+-- allocation, if any, is held back from every other phase until the phase
+-- ends; what it did not sell then rolls into whatever is still on sale.
+-- Phases are keyed by name, and each registration records the phase that
+-- sold it, so a phase's sales are counted from registrations under the
+-- event row lock rather than kept in a counter.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS sale_phases (
+    event_id   TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name       TEXT        NOT NULL,
+    starts_at  TIMESTAMPTZ NOT NULL,
+    ends_at    TIMESTAMPTZ,
+    code       TEXT,
+    allocation INTEGER     CHECK (allocation > 0),
+
+    PRIMARY KEY (event_id, name),
+    CONSTRAINT sale_phase_window CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+ALTER TABLE registrations
+    ADD COLUMN IF NOT EXISTS sale_phase TEXT;
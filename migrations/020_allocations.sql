@@ -0,0 +1,47 @@
+-- migrations/020_allocations.sql
+-- Named seat allocations held back from public sale, and complimentary
+-- registrations issued against them.
+-- Run with: psql -U postgres -d eventbooking -f migrations/020_allocations.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- ALLOCATIONS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- An allocation holds seats for speakers, sponsors and the like.  Each comp
+-- records its allocation by name, so what an allocation has issued is
+-- counted from registrations.  allocation_remaining on the event is the sum
+-- of every allocation's unissued seats, kept in step under the event row
+-- lock like booked_count, so public availability stays a column expression.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS allocations (
+    event_id   TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name       TEXT        NOT NULL,
+    seats      INTEGER     NOT NULL CHECK (seats > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (event_id, name)
+);
+
+ALTER TABLE registrations
+    ADD COLUMN IF NOT EXISTS allocation TEXT;
+
+-- Held seats are part of capacity: public bookings, which only move
+-- booked_count, can never take them, and a comp moves a seat from one
+-- column to the other.  The guard is only added once, and not at all once
+-- 021 has replaced it with within_sellable_capacity, so the file can be run
+-- again.
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS allocation_remaining INTEGER NOT NULL DEFAULT 0
+        CHECK (allocation_remaining >= 0);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conrelid = 'events'::regclass
+          AND conname IN ('held_within_capacity', 'within_sellable_capacity')
+    ) THEN
+        ALTER TABLE events
+            ADD CONSTRAINT held_within_capacity CHECK (booked_count + allocation_remaining <= capacity);
+    END IF;
+END
+$$;
@@ -0,0 +1,46 @@
+-- migrations/021_oversell.sql
+-- Controlled overbooking: an optional oversell allowance per event, and the
+-- move of no_overbooking from physical to sellable capacity.
+-- Run with: psql -U postgres -d eventbooking -f migrations/021_oversell.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- SELLABLE CAPACITY
+-- ─────────────────────────────────────────────────────────────────────────────
+-- capacity stays the physical size of the room.  An event may oversell by a
+-- percentage of it or by a fixed number of seats, not both; the percentage
+-- rounds down.  sellable_capacity is generated from the three columns, so
+-- the guard below and the application (model.Event.SellableCapacity) can
+-- never disagree about the cap.
+--
+-- The old guards, no_overbooking (001) and held_within_capacity (020), cap
+-- booked and held seats at capacity.  They are replaced by one guard on
+-- sellable_capacity in a single transaction: the new constraint is added
+-- first, so there is no moment without a guard, and it is looser than the
+-- old ones with every allowance at 0, so every existing row satisfies it.
+-- Adding the stored column rewrites the table under an exclusive lock; run
+-- it outside peak hours.  Each constraint is dropped if present before it is
+-- added, so the file can be run again.
+-- ─────────────────────────────────────────────────────────────────────────────
+BEGIN;
+
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS oversell_percent INTEGER NOT NULL DEFAULT 0
+        CHECK (oversell_percent BETWEEN 0 AND 100),
+    ADD COLUMN IF NOT EXISTS oversell_seats INTEGER NOT NULL DEFAULT 0
+        CHECK (oversell_seats >= 0);
+
+ALTER TABLE events
+    DROP CONSTRAINT IF EXISTS oversell_one_way,
+    ADD CONSTRAINT oversell_one_way CHECK (oversell_percent = 0 OR oversell_seats = 0),
+    ADD COLUMN IF NOT EXISTS sellable_capacity INTEGER
+        GENERATED ALWAYS AS (capacity + oversell_seats + capacity * oversell_percent / 100) STORED;
+
+ALTER TABLE events
+    DROP CONSTRAINT IF EXISTS within_sellable_capacity,
+    ADD CONSTRAINT within_sellable_capacity CHECK (booked_count + allocation_remaining <= sellable_capacity);
+
+ALTER TABLE events
+    DROP CONSTRAINT IF EXISTS no_overbooking,
+    DROP CONSTRAINT IF EXISTS held_within_capacity;
+
+COMMIT;
@@ -0,0 +1,45 @@
+-- migrations/022_seat_maps.sql
+-- Seat maps per venue, and reserved seating: registrations that hold a
+-- specific seat.
+-- Run with: psql -U postgres -d eventbooking -f migrations/022_seat_maps.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- SEAT MAPS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- A venue's seats are stored flat; position keeps the order the organizer
+-- drew them in, from which the sections and rows are rebuilt.  A seat is
+-- identified by section, row and label within its venue, so saving the map
+-- again keeps the ids of the seats it still has.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS venue_seats (
+    id         TEXT    PRIMARY KEY,
+    venue_id   TEXT    NOT NULL REFERENCES venues(id) ON DELETE CASCADE,
+    section    TEXT    NOT NULL CHECK (char_length(section)   BETWEEN 1 AND 50),
+    row_label  TEXT    NOT NULL CHECK (char_length(row_label) BETWEEN 1 AND 10),
+    label      TEXT    NOT NULL CHECK (char_length(label)     BETWEEN 1 AND 10),
+    accessible BOOLEAN NOT NULL DEFAULT FALSE,
+    position   INTEGER NOT NULL,
+
+    UNIQUE (venue_id, section, row_label, label)
+);
+
+CREATE INDEX IF NOT EXISTS idx_venue_seats_venue_position ON venue_seats(venue_id, position);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- RESERVED SEATING
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Events with reserved_seating sell seats of their venue's map; each
+-- registration records its seat.  Seats are still counted by booked_count,
+-- so every capacity check is unchanged.  A seat in use cannot be removed
+-- from the map (the foreign key), and the unique index below is the
+-- per-seat counterpart of no_overbooking: one registration per seat per
+-- event, whatever the application does.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS reserved_seating BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE registrations
+    ADD COLUMN IF NOT EXISTS seat_id TEXT REFERENCES venue_seats(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS one_registration_per_seat
+    ON registrations(event_id, seat_id) WHERE seat_id IS NOT NULL;
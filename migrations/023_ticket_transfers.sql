@@ -0,0 +1,41 @@
+-- migrations/023_ticket_transfers.sql
+-- Ticket codes on registrations, and transfers of a ticket to another
+-- attendee.
+-- Run with: psql -U postgres -d eventbooking -f migrations/023_ticket_transfers.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- TICKET CODES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- The ticket code is the holder's secret: it is sent with the confirmation
+-- and authorizes a transfer.  Like unsubscribe tokens, it is two random
+-- UUIDs (~244 bits) so no extension is needed.  The default is volatile, so
+-- every existing registration gets a code of its own.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE registrations
+    ADD COLUMN IF NOT EXISTS ticket_code TEXT NOT NULL
+        DEFAULT replace(gen_random_uuid()::text, '-', '') || replace(gen_random_uuid()::text, '-', '');
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registrations_ticket_code ON registrations(ticket_code);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- TRANSFERS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- A transfer moves a registration to a new email in place and replaces its
+-- ticket code; unique_registration still holds for the new email.  The
+-- history outlives the registration (no foreign key to it), so a ticket
+-- cancelled after changing hands keeps its trail until the event is deleted.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS transfers_disabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS ticket_transfers (
+    id              TEXT        PRIMARY KEY,
+    event_id        TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    registration_id TEXT        NOT NULL,
+    from_email      TEXT        NOT NULL,
+    to_email        TEXT        NOT NULL CHECK (to_email <> from_email),
+    transferred_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_transfers_registration
+    ON ticket_transfers(registration_id, transferred_at);
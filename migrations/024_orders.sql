@@ -0,0 +1,88 @@
+-- migrations/024_orders.sql
+-- Paid tickets: prices on events, orders that hold a seat while payment is
+-- pending, and the payment provider's callbacks.
+-- Run with: psql -U postgres -d eventbooking -f migrations/024_orders.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- PRICES AND HELD SEATS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- price_cents is in the minor unit of currency (ISO 4217); 0 means free,
+-- and free events book exactly as before.  pending_count is the number of
+-- seats held by orders awaiting payment.  Like allocation_remaining it is
+-- maintained under the event row lock, and the capacity guard is extended
+-- to cover it; the drop and add are one statement, so there is no moment
+-- without a guard.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS price_cents   INTEGER NOT NULL DEFAULT 0 CHECK (price_cents >= 0),
+    ADD COLUMN IF NOT EXISTS currency      TEXT    NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$'),
+    ADD COLUMN IF NOT EXISTS pending_count INTEGER NOT NULL DEFAULT 0 CHECK (pending_count >= 0);
+
+ALTER TABLE events
+    DROP CONSTRAINT IF EXISTS within_sellable_capacity,
+    ADD CONSTRAINT within_sellable_capacity
+        CHECK (booked_count + allocation_remaining + pending_count <= sellable_capacity);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- ORDERS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- An order buys one ticket.  It goes pending → paid → fulfilled, or
+-- pending → failed / expired.  A pending order holds its seat (and its
+-- reserved seat, if any) until expires_at; fulfilment books the
+-- registration.  The amount is captured when the order is placed, so a
+-- later price change does not affect it.  registration_id has no foreign
+-- key: a cancelled registration's order is kept as the payment record.  A
+-- seat removed from the seat map is only ever held by a finished order
+-- (SetSeatMap refuses otherwise), which then just forgets it.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS orders (
+    id              TEXT        PRIMARY KEY,
+    event_id        TEXT        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_email      TEXT        NOT NULL,
+    attendee_name   TEXT        NOT NULL DEFAULT '',
+    answers         JSONB       NOT NULL DEFAULT '{}' CHECK (jsonb_typeof(answers) = 'object'),
+    invite_code     TEXT,
+    sale_phase      TEXT,
+    seat_id         TEXT        REFERENCES venue_seats(id) ON DELETE SET NULL,
+    amount_cents    INTEGER     NOT NULL CHECK (amount_cents > 0),
+    currency        TEXT        NOT NULL,
+    status          TEXT        NOT NULL DEFAULT 'pending'
+                                CHECK (status IN ('pending', 'paid', 'fulfilled', 'failed', 'expired')),
+    provider        TEXT        NOT NULL,
+    payment_id      TEXT,
+    checkout_url    TEXT        NOT NULL DEFAULT '',
+    registration_id TEXT,
+    failure_reason  TEXT        NOT NULL DEFAULT '',
+    expires_at      TIMESTAMPTZ NOT NULL,
+    paid_at         TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE (provider, payment_id),
+    CONSTRAINT fulfilled_has_registration CHECK ((status = 'fulfilled') = (registration_id IS NOT NULL))
+);
+
+-- One order awaiting payment per email and per reserved seat.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_pending_email
+    ON orders(event_id, user_email) WHERE status = 'pending';
+CREATE UNIQUE INDEX IF NOT EXISTS one_pending_order_per_seat
+    ON orders(event_id, seat_id) WHERE status = 'pending' AND seat_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_pending_expiry ON orders(expires_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_orders_event_created ON orders(event_id, created_at);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- PAYMENT CALLBACKS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Every callback the provider delivers is recorded under its own id, in the
+-- transaction that applies it.  A redelivered callback conflicts here and
+-- changes nothing.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS payment_callbacks (
+    provider    TEXT        NOT NULL,
+    id          TEXT        NOT NULL,
+    payment_id  TEXT        NOT NULL,
+    status      TEXT        NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (provider, id)
+);
@@ -0,0 +1,74 @@
+-- migrations/025_refunds.sql
+-- Refund policies for paid events, and refunds owed on cancelled or
+-- unbookable orders, retried until the payment provider confirms them.
+-- Run with: psql -U postgres -d eventbooking -f migrations/025_refunds.sql
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- REFUND POLICIES
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Each rule refunds percent of the price to a cancellation made at least
+-- days_before days before the event starts; the rule with the largest
+-- days_before that still applies wins.  "Full refund up to 7 days before,
+-- 50% after" is (7, 100) and (0, 50).  An event without rules refunds
+-- nothing.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS refund_rules (
+    event_id    TEXT    NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    days_before INTEGER NOT NULL CHECK (days_before >= 0),
+    percent     INTEGER NOT NULL CHECK (percent BETWEEN 0 AND 100),
+
+    PRIMARY KEY (event_id, days_before)
+);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- CANCELLED ORDERS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- A fulfilled order whose registration is cancelled becomes cancelled.  It
+-- keeps its registration_id as the record of what it paid for.
+-- orders_status_check is the name Postgres gave 024's inline status check.
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_status_check,
+    ADD CONSTRAINT orders_status_check
+        CHECK (status IN ('pending', 'paid', 'fulfilled', 'cancelled', 'failed', 'expired')),
+    DROP CONSTRAINT IF EXISTS fulfilled_has_registration,
+    ADD CONSTRAINT fulfilled_has_registration
+        CHECK ((status IN ('fulfilled', 'cancelled')) = (registration_id IS NOT NULL));
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- REFUNDS
+-- ─────────────────────────────────────────────────────────────────────────────
+-- A refund is written in the same transaction that releases the seat, so
+-- the seat and the money owed can never disagree; sending it to the
+-- provider happens afterwards and is retried with backoff.  A worker claims
+-- a refund by pushing next_attempt_at past a lease, as jobs do, so a crash
+-- mid-attempt is retried once the lease runs out.  The provider dedupes on
+-- the refund id, so a retry never pays twice.  One refund per order.
+-- A refund is the record of money owed or returned, so it never cascades:
+-- its event and order cannot be deleted while it exists.
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE IF NOT EXISTS refunds (
+    id                 TEXT        PRIMARY KEY,
+    event_id           TEXT        NOT NULL REFERENCES events(id) ON DELETE RESTRICT,
+    order_id           TEXT        NOT NULL UNIQUE REFERENCES orders(id) ON DELETE RESTRICT,
+    registration_id    TEXT,
+    reason             TEXT        NOT NULL CHECK (reason IN ('cancelled', 'unbookable')),
+    percent            INTEGER     NOT NULL CHECK (percent BETWEEN 1 AND 100),
+    amount_cents       INTEGER     NOT NULL CHECK (amount_cents > 0),
+    currency           TEXT        NOT NULL,
+    status             TEXT        NOT NULL DEFAULT 'pending'
+                                   CHECK (status IN ('pending', 'succeeded', 'failed')),
+    provider_refund_id TEXT,
+    attempts           INTEGER     NOT NULL DEFAULT 0,
+    last_error         TEXT        NOT NULL DEFAULT '',
+    next_attempt_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    refunded_at        TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT succeeded_has_provider_refund
+        CHECK ((status = 'succeeded') = (provider_refund_id IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_due ON refunds(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_refunds_event_created ON refunds(event_id, created_at);